@@ -0,0 +1,152 @@
+package aerospike
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+const (
+	// maxBinNameLength is Aerospike bin name length limit
+	maxBinNameLength = 15
+	// binAliasSet is a set storing bin alias table records keyed by set name
+	binAliasSet = "_bin_aliases"
+	// binAliasBin is alias table map bin with logical column name keys and physical bin name values
+	binAliasBin = "aliases"
+)
+
+// WithBinAliasing maps column names longer than Aerospike bin name limit to short physical bins,
+// aliases are persisted in the namespace alias table, so that all clients share the same mapping
+func WithBinAliasing() Option {
+	return func(s *set) {
+		s.binAliasing = true
+	}
+}
+
+// isBinField returns true if field value is stored in its own record bin
+func (f *field) isBinField() bool {
+	return !(f.tag.Ignore || f.isPseudo || f.isFunc || f.isMeta || f.tag.IsGeneration || f.tag.IsTTL || len(f.path) > 0)
+}
+
+// isAliasable returns true if field bin can be aliased, key and index columns are matched by name in criteria
+func (f *field) isAliasable() bool {
+	return !(f.tag.IsPK || f.tag.IsMapKey || f.tag.IsArrayIndex || f.tag.IsSecondaryIndex)
+}
+
+// binNameViolations returns set bin names exceeding Aerospike limit that can not be aliased
+func (s *set) binNameViolations(aMapper *mapper) []error {
+	name := s.xType.Name
+	if idx := strings.Index(name, "/"); idx != -1 {
+		if bin := name[idx+1:]; len(bin) > maxBinNameLength {
+			return []error{fmt.Errorf("collection bin name %v exceeds %v characters", bin, maxBinNameLength)}
+		}
+		return nil
+	}
+	var violations []error
+	for i := range aMapper.fields {
+		aField := &aMapper.fields[i]
+		if !aField.isBinField() || len(aField.Column()) <= maxBinNameLength {
+			continue
+		}
+		if !s.binAliasing {
+			violations = append(violations, fmt.Errorf("bin name %v exceeds %v characters, use shorter tag name or WithBinAliasing option", aField.Column(), maxBinNameLength))
+		} else if !aField.isAliasable() {
+			violations = append(violations, fmt.Errorf("key or index bin name %v exceeds %v characters", aField.Column(), maxBinNameLength))
+		}
+	}
+	return violations
+}
+
+// binAlias returns deterministic short physical bin name of a long column name
+func binAlias(column string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(column))
+	return fmt.Sprintf("%s_%04x", column[:maxBinNameLength-5], hash.Sum32()&0xffff)
+}
+
+// aliasedFields returns bin fields with column names exceeding bin name limit
+func (m *mapper) aliasedFields() []*field {
+	var result []*field
+	for i := range m.fields {
+		aField := &m.fields[i]
+		if aField.isBinField() && aField.isAliasable() && len(aField.tag.Name) > maxBinNameLength {
+			result = append(result, aField)
+		}
+	}
+	return result
+}
+
+// loadBinAliases returns set type based mapper with physical bins of long column names from the persisted alias table,
+// missing aliases are added with create only map policy, so that concurrent clients end up with the first stored mapping,
+// aliased mapper copy replaces set mapper, so that mappers used by other statements are never modified
+func (s *Statement) loadBinAliases(ctx context.Context, aSet *set) (*mapper, error) {
+	aSet.mux.Lock()
+	defer aSet.mux.Unlock()
+	aMapper := aSet.typeBasedMapper
+	if aSet.binAliases != nil {
+		return aMapper, nil
+	}
+	fields := aMapper.aliasedFields()
+	if len(fields) == 0 {
+		aSet.binAliases = map[string]string{}
+		return aMapper, nil
+	}
+	key, keyErr := as.NewKey(s.namespace, binAliasSet, s.set)
+	if keyErr != nil {
+		return nil, keyErr
+	}
+	candidates := make(map[interface{}]interface{}, len(fields))
+	for _, aField := range fields {
+		candidates[aField.tag.Name] = binAlias(aField.tag.Name)
+	}
+	policy := as.NewMapPolicyWithFlags(as.MapOrder.KEY_ORDERED, as.MapWriteFlagsCreateOnly|as.MapWriteFlagsNoFail|as.MapWriteFlagsPartial)
+	if _, opErr := s.operateWithCtx(ctx, nil, key, []*as.Operation{as.MapPutItemsOp(policy, binAliasBin, candidates)}); opErr != nil {
+		return nil, fmt.Errorf("unable to store %v bin aliases: %w", s.set, opErr)
+	}
+	record, err := s.getWithCtx(ctx, nil, key, []string{binAliasBin})
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %v bin aliases: %w", s.set, err)
+	}
+	table, _ := record.Bins[binAliasBin].(map[interface{}]interface{})
+	aliases := make(map[string]string, len(fields))
+	used := map[string]string{}
+	for i := range aMapper.fields {
+		if aField := &aMapper.fields[i]; aField.isBinField() {
+			used[aField.Column()] = aField.tag.Name
+		}
+	}
+	for _, aField := range fields {
+		bin, ok := table[aField.tag.Name].(string)
+		if !ok || bin == "" || len(bin) > maxBinNameLength {
+			return nil, fmt.Errorf("invalid %v.%v bin alias: %v", s.set, aField.tag.Name, table[aField.tag.Name])
+		}
+		if column, ok := used[bin]; ok && column != aField.tag.Name {
+			return nil, fmt.Errorf("%v.%v bin alias %v conflicts with column %v", s.set, aField.tag.Name, bin, column)
+		}
+		used[bin] = aField.tag.Name
+		aliases[aField.tag.Name] = bin
+	}
+	aliased, err := newAliasedMapper(aSet.xType.Type, aliases)
+	if err != nil {
+		return nil, err
+	}
+	aSet.typeBasedMapper = aliased
+	aSet.binAliases = aliases
+	return aliased, nil
+}
+
+// newAliasedMapper creates type based mapper with aliased fields using physical bins
+func newAliasedMapper(recordType reflect.Type, aliases map[string]string) (*mapper, error) {
+	aMapper, err := newTypeBasedMapper(recordType)
+	if err != nil {
+		return nil, err
+	}
+	for _, aField := range aMapper.aliasedFields() {
+		aField.bin = aliases[aField.tag.Name]
+	}
+	return aMapper, nil
+}
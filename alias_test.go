@@ -0,0 +1,90 @@
+package aerospike
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/x"
+	"reflect"
+	"testing"
+)
+
+func Test_validateBinNames(t *testing.T) {
+	type Short struct {
+		Id   int    `aerospike:"id,pk=true"`
+		Name string `aerospike:"name"`
+	}
+	type Long struct {
+		Id       int    `aerospike:"id,pk=true"`
+		Lifetime int    `aerospike:"customer_lifetime_value"`
+		Internal string `aerospike:"internal_description_text,-"`
+		Gen      int    `aerospike:"record_generation_number,generation"`
+	}
+	type LongKey struct {
+		Id int `aerospike:"customer_identifier,pk=true"`
+	}
+	var testCases = []struct {
+		description string
+		name        string
+		rType       reflect.Type
+		options     []Option
+		expectErr   bool
+	}{
+		{description: "short bins", name: "shorts", rType: reflect.TypeOf(Short{})},
+		{description: "long bin", name: "longs", rType: reflect.TypeOf(Long{}), expectErr: true},
+		{description: "long bin with aliasing", name: "longs", rType: reflect.TypeOf(Long{}), options: []Option{WithBinAliasing()}},
+		{description: "long key with aliasing", name: "keys", rType: reflect.TypeOf(LongKey{}), options: []Option{WithBinAliasing()}, expectErr: true},
+		{description: "long collection bin", name: "Board/scores_by_player_name", rType: reflect.TypeOf(Short{}), expectErr: true},
+	}
+	for _, testCase := range testCases {
+		aSet := &set{xType: x.NewType(testCase.rType, x.WithName(testCase.name))}
+		for _, option := range testCase.options {
+			option(aSet)
+		}
+		err := newRegistry().Register(aSet)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+	}
+}
+
+func Test_binAlias(t *testing.T) {
+	type Long struct {
+		Id       int `aerospike:"id,pk=true"`
+		Lifetime int `aerospike:"customer_lifetime_value"`
+	}
+	alias := binAlias("customer_lifetime_value")
+	assert.Len(t, alias, maxBinNameLength)
+	assert.Equal(t, alias, binAlias("customer_lifetime_value"))
+	assert.NotEqual(t, alias, binAlias("customer_lifetime_total"))
+
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Long{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	fields := aMapper.aliasedFields()
+	if assert.Len(t, fields, 1) {
+		fields[0].bin = alias
+		assert.Equal(t, alias, aMapper.getField("customer_lifetime_value").Column())
+		assert.Equal(t, "customer_lifetime_value", aMapper.getField("customer_lifetime_value").tag.Name)
+	}
+}
+
+func Test_newAliasedMapper(t *testing.T) {
+	type Long struct {
+		Id       int `aerospike:"id,pk=true"`
+		Lifetime int `aerospike:"customer_lifetime_value"`
+	}
+	alias := binAlias("customer_lifetime_value")
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Long{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	aliased, err := newAliasedMapper(reflect.TypeOf(Long{}), map[string]string{"customer_lifetime_value": alias})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, alias, aliased.getField("customer_lifetime_value").Column())
+	assert.Equal(t, "id", aliased.getField("id").Column())
+	assert.Equal(t, "customer_lifetime_value", aMapper.getField("customer_lifetime_value").Column())
+}
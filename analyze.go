@@ -0,0 +1,238 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/viant/sqlparser"
+)
+
+// kindAnalyzeSet represents ANALYZE SET name [SAMPLE n] statement
+const kindAnalyzeSet sqlparser.Kind = "analyze set"
+
+// defaultAnalyzeSampleSize is the default number of records scanned by ANALYZE SET
+const defaultAnalyzeSampleSize = 1000
+
+var analyzeSetExpr = regexp.MustCompile(`(?i)^\s*ANALYZE\s+SET\s+([^\s;]+)(?:\s+SAMPLE\s+(\d+))?\s*;?\s*$`)
+
+// binAnalysis represents ANALYZE SET result row
+type binAnalysis struct {
+	Bin      string  `sqlx:"bin" aerospike:"bin,pk=true"`
+	Types    string  `sqlx:"types" aerospike:"types"`
+	FillRate float64 `sqlx:"fill_rate" aerospike:"fill_rate"`
+	MinSize  int     `sqlx:"min_size" aerospike:"min_size"`
+	MaxSize  int     `sqlx:"max_size" aerospike:"max_size"`
+	Declared bool    `sqlx:"declared" aerospike:"declared"`
+}
+
+type binStats struct {
+	types   map[string]bool
+	count   int
+	minSize int
+	maxSize int
+}
+
+func (s *Statement) prepareAnalyzeSet(SQL string) error {
+	match := analyzeSetExpr.FindStringSubmatch(SQL)
+	if match == nil {
+		return fmt.Errorf("invalid analyze set statement: %v", SQL)
+	}
+	s.setSet(match[1])
+	s.sampleSize = defaultAnalyzeSampleSize
+	if match[2] != "" {
+		sampleSize, err := strconv.Atoi(match[2])
+		if err != nil || sampleSize <= 0 {
+			return fmt.Errorf("invalid analyze set sample: %v", match[2])
+		}
+		s.sampleSize = sampleSize
+	}
+	return nil
+}
+
+// handleAnalyzeSet scans sample set records and returns a row per bin with observed particle types, fill rate,
+// min and max size, and whether the bin is declared by the registered set type
+func (s *Statement) handleAnalyzeSet(ctx context.Context) (driver.Rows, error) {
+	records, err := s.sampleRecords(ctx, s.sampleSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to analyze set %v: %w", s.set, err)
+	}
+	return s.analysisRows(ctx, records)
+}
+
+// analysisRows returns bin analysis rows of sampled records
+func (s *Statement) analysisRows(ctx context.Context, records []*as.Record) (driver.Rows, error) {
+	declared, err := s.declaredBins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to analyze set %v: %w", s.set, err)
+	}
+	recordType := reflect.TypeOf(binAnalysis{})
+	aMapper, err := newTypeBasedMapper(recordType)
+	if err != nil {
+		return nil, err
+	}
+	s.recordType = recordType
+	rows := s.newRows(ctx, aMapper)
+	rows.rowsReader = newRowsReader(analyzeBins(records, declared))
+	return rows, nil
+}
+
+// declaredBins returns bins declared by set type, collection sets registered as set/bin declare their collection bin
+// together with mapped bins, aliased columns of sets with bin aliasing declare their physical bins
+func (s *Statement) declaredBins(ctx context.Context) (map[string]bool, error) {
+	result := map[string]bool{}
+	for _, name := range s.sets.sets() {
+		if name != s.set && !strings.HasPrefix(name, s.set+"/") {
+			continue
+		}
+		aSet := s.sets.Lookup(name)
+		aMapper, err := aSet.lookupTypeBasedMapper(aSet.xType.Type)
+		if err != nil {
+			return nil, err
+		}
+		if idx := strings.Index(name, "/"); idx != -1 {
+			result[name[idx+1:]] = true
+		} else if aSet.binAliasing {
+			if aMapper, err = s.loadBinAliases(ctx, aSet); err != nil {
+				return nil, err
+			}
+		}
+		for i := range aMapper.fields {
+			aField := &aMapper.fields[i]
+			if !aField.isBinField() {
+				continue
+			}
+			if bin, ok := aSet.binAliases[aField.tag.Name]; ok {
+				result[bin] = true
+				continue
+			}
+			result[aField.Column()] = true
+		}
+	}
+	return result, nil
+}
+
+// analyzeBins returns bin analysis records of sampled records, declared bins missing in all records have zero fill rate
+func analyzeBins(records []*as.Record, declared map[string]bool) []*as.Record {
+	stats := map[string]*binStats{}
+	for _, record := range records {
+		for bin, value := range record.Bins {
+			if value == nil {
+				continue
+			}
+			stat, ok := stats[bin]
+			if !ok {
+				stat = &binStats{types: map[string]bool{}, minSize: -1}
+				stats[bin] = stat
+			}
+			stat.count++
+			stat.types[particleTypeName(value)] = true
+			size := particleSize(value)
+			if stat.minSize == -1 || size < stat.minSize {
+				stat.minSize = size
+			}
+			if size > stat.maxSize {
+				stat.maxSize = size
+			}
+		}
+	}
+	for bin := range declared {
+		if _, ok := stats[bin]; !ok {
+			stats[bin] = &binStats{types: map[string]bool{}}
+		}
+	}
+	bins := make([]string, 0, len(stats))
+	for bin := range stats {
+		bins = append(bins, bin)
+	}
+	sort.Strings(bins)
+	result := make([]*as.Record, 0, len(bins))
+	for _, bin := range bins {
+		stat := stats[bin]
+		types := make([]string, 0, len(stat.types))
+		for name := range stat.types {
+			types = append(types, name)
+		}
+		sort.Strings(types)
+		fillRate := 0.0
+		if len(records) > 0 {
+			fillRate = float64(stat.count) / float64(len(records))
+		}
+		minSize := stat.minSize
+		if minSize < 0 {
+			minSize = 0
+		}
+		result = append(result, &as.Record{Bins: as.BinMap{
+			"bin":       bin,
+			"types":     strings.Join(types, ","),
+			"fill_rate": fillRate,
+			"min_size":  minSize,
+			"max_size":  stat.maxSize,
+			"declared":  declared[bin],
+		}})
+	}
+	return result
+}
+
+// particleTypeName returns Aerospike particle type name of a bin value
+func particleTypeName(value interface{}) string {
+	switch value.(type) {
+	case as.GeoJSONValue:
+		return "GEOJSON"
+	case as.HLLValue:
+		return "HLL"
+	case string:
+		return "STRING"
+	case []byte:
+		return "BLOB"
+	case bool:
+		return "BOOL"
+	case float32, float64:
+		return "FLOAT"
+	case []interface{}:
+		return "LIST"
+	case map[interface{}]interface{}:
+		return "MAP"
+	}
+	if isNumericKind(reflect.ValueOf(value).Kind()) {
+		return "INTEGER"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// particleSize returns approximate bin value size in bytes, collection size is the sum of its keys and values sizes
+func particleSize(value interface{}) int {
+	switch actual := value.(type) {
+	case nil:
+		return 0
+	case as.GeoJSONValue:
+		return len(actual)
+	case as.HLLValue:
+		return len(actual)
+	case string:
+		return len(actual)
+	case []byte:
+		return len(actual)
+	case bool:
+		return 1
+	case []interface{}:
+		size := 0
+		for _, item := range actual {
+			size += particleSize(item)
+		}
+		return size
+	case map[interface{}]interface{}:
+		size := 0
+		for key, item := range actual {
+			size += particleSize(key) + particleSize(item)
+		}
+		return size
+	}
+	return 8
+}
@@ -0,0 +1,100 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/x"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_analyzeSet(t *testing.T) {
+	type User struct {
+		Id     int    `aerospike:"id,pk"`
+		Name   string `aerospike:"name"`
+		Active bool   `aerospike:"active"`
+		Skip   string `aerospike:"-"`
+	}
+	aRegistry := NewRegistry()
+	if !assert.Nil(t, aRegistry.RegisterSet(x.NewType(reflect.TypeOf(User{}), x.WithName("users")))) {
+		return
+	}
+	stmt := &Statement{kind: parseKind("ANALYZE SET users SAMPLE 10"), sets: aRegistry}
+	assert.Equal(t, kindAnalyzeSet, stmt.kind)
+	if !assert.Nil(t, stmt.prepareAnalyzeSet("ANALYZE SET users SAMPLE 10")) {
+		return
+	}
+	assert.Equal(t, "users", stmt.set)
+	assert.Equal(t, 10, stmt.sampleSize)
+
+	records := []*as.Record{
+		{Bins: as.BinMap{"id": 1, "name": "Bob", "legacy": []byte("abc")}},
+		{Bins: as.BinMap{"id": 2, "name": "Alice", "legacy": "x"}},
+		{Bins: as.BinMap{"id": 3, "name": nil, "tags": []interface{}{"a", "bc"}}},
+		{Bins: as.BinMap{"id": 4, "name": "Al"}},
+	}
+	rows, err := stmt.analysisRows(context.Background(), records)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"bin", "types", "fill_rate", "min_size", "max_size", "declared"}, rows.Columns())
+	var actual []string
+	for {
+		dest := make([]driver.Value, len(rows.Columns()))
+		if err = rows.Next(dest); err == io.EOF {
+			break
+		}
+		if !assert.Nil(t, err) {
+			return
+		}
+		actual = append(actual, fmt.Sprintf("%v", dest)) //rows reuse record memory
+	}
+	assert.Equal(t, []string{
+		"[active  0 0 0 true]",
+		"[id INTEGER 1 8 8 true]",
+		"[legacy BLOB,STRING 0.5 1 3 false]",
+		"[name STRING 0.75 2 5 true]",
+		"[tags LIST 0.25 3 3 false]",
+	}, actual)
+
+	assert.NotNil(t, (&Statement{}).prepareAnalyzeSet("ANALYZE SET users SAMPLE x"))
+}
+
+func Test_declaredBins(t *testing.T) {
+	type Account struct {
+		Id       int     `aerospike:"id,pk"`
+		Lifetime float64 `aerospike:"customer_lifetime_value"`
+	}
+	aRegistry := NewRegistry()
+	if !assert.Nil(t, aRegistry.RegisterSet(x.NewType(reflect.TypeOf(Account{}), x.WithName("accounts")), WithBinAliasing())) {
+		return
+	}
+	aRegistry.Lookup("accounts").binAliases = map[string]string{"customer_lifetime_value": "customer_l_1a2b"}
+	stmt := &Statement{sets: aRegistry}
+	if !assert.Nil(t, stmt.prepareAnalyzeSet("ANALYZE SET accounts")) {
+		return
+	}
+	declared, err := stmt.declaredBins(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"id": true, "customer_l_1a2b": true}, declared)
+
+	type Score struct {
+		Id     string `aerospike:"id,pk=true"`
+		Player string `aerospike:"player,mapKey"`
+		Points int    `aerospike:"points"`
+	}
+	if !assert.Nil(t, aRegistry.RegisterSet(x.NewType(reflect.TypeOf(Score{}), x.WithName("boards/scores")))) {
+		return
+	}
+	stmt = &Statement{sets: aRegistry}
+	if !assert.Nil(t, stmt.prepareAnalyzeSet("ANALYZE SET boards")) {
+		return
+	}
+	declared, err = stmt.declaredBins(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"scores": true, "id": true, "player": true, "points": true}, declared)
+}
@@ -0,0 +1,99 @@
+package aerospike
+
+import (
+	"fmt"
+	"reflect"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+const (
+	bitSetFunction   = "bit_set"
+	bitGetFunction   = "bit_get"
+	bitCountFunction = "bit_count"
+)
+
+// bitSetOperations returns operations setting bits of []byte bin, BIT_SET(col, offset, value) sets a single bit,
+// BIT_SET(col, offset, size, bytes) sets size bits from supplied bytes, the bin grows when needed
+func bitSetOperations(aField *field, values []interface{}) ([]*as.Operation, error) {
+	if !isBytesType(aField.Type) {
+		return nil, fmt.Errorf("invalid %v call: column %v is not []byte", bitSetFunction, aField.Column())
+	}
+	var offset, size int
+	var value []byte
+	var err error
+	switch len(values) {
+	case 2:
+		if offset, err = asIndex(values[0]); err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", bitSetFunction, err)
+		}
+		size = 1
+		bit, err := asBit(values[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", bitSetFunction, err)
+		}
+		value = []byte{bit << 7}
+	case 3:
+		if offset, err = asIndex(values[0]); err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", bitSetFunction, err)
+		}
+		if size, err = asIndex(values[1]); err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", bitSetFunction, err)
+		}
+		var ok bool
+		if value, ok = values[2].([]byte); !ok {
+			return nil, fmt.Errorf("invalid %v call: expected []byte value but had: %T", bitSetFunction, values[2])
+		}
+	default:
+		return nil, fmt.Errorf("invalid %v call: expected offset and value or offset, size and bytes", bitSetFunction)
+	}
+	if offset < 0 || size <= 0 {
+		return nil, fmt.Errorf("invalid %v call: invalid offset: %v or size: %v", bitSetFunction, offset, size)
+	}
+	policy := as.DefaultBitPolicy()
+	byteSize := (offset + size + 7) / 8
+	return []*as.Operation{
+		as.BitResizeOp(policy, aField.Column(), byteSize, as.BitResizeFlagsGrowOnly),
+		as.BitSetOp(policy, aField.Column(), offset, size, value),
+	}, nil
+}
+
+// bitReadOperation returns BIT_GET or BIT_COUNT read operation
+func bitReadOperation(function *binFunction) (*as.Operation, error) {
+	if len(function.args) != 2 {
+		return nil, fmt.Errorf("invalid %v call: expected offset and size", function.name)
+	}
+	offset, err := asIndex(function.args[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v call: %w", function.name, err)
+	}
+	size, err := asIndex(function.args[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v call: %w", function.name, err)
+	}
+	if function.name == bitGetFunction {
+		return as.BitGetOp(function.bin, offset, size), nil
+	}
+	return as.BitCountOp(function.bin, offset, size), nil
+}
+
+func asBit(value interface{}) (byte, error) {
+	if b, ok := value.(bool); ok {
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	bit, err := asIndex(value)
+	if err != nil || (bit != 0 && bit != 1) {
+		return 0, fmt.Errorf("invalid bit value: %v", value)
+	}
+	return byte(bit), nil
+}
+
+func isBytesType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
@@ -0,0 +1,226 @@
+package aerospike
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/viant/sqlparser"
+	"github.com/viant/sqlparser/expr"
+	"github.com/viant/sqlparser/node"
+	"github.com/viant/xunsafe"
+)
+
+const (
+	listAppendFunction      = "list_append"
+	listRemoveValueFunction = "list_remove_value"
+	listTrimFunction        = "list_trim"
+	listInsertFunction      = "list_insert"
+	mapPutFunction          = "map_put"
+	mapRemoveFunction       = "map_remove"
+	mapIncrementFunction    = "map_increment"
+)
+
+// cdtOperations converts update column CDT, HLL or bit function call i.e. SET tags = LIST_APPEND(tags, ?) into aerospike operations
+func (s *Statement) cdtOperations(aSet *set, aField *field, call *expr.Call, args []driver.NamedValue, argIndex *int) ([]*as.Operation, error) {
+	name := strings.ToLower(sqlparser.Stringify(call.X))
+	if len(call.Args) == 0 {
+		return nil, fmt.Errorf("invalid %v call: missing %v column argument", name, aField.Column())
+	}
+	if column := strings.Trim(sqlparser.Stringify(call.Args[0]), "`"); !strings.EqualFold(column, aField.Column()) {
+		return nil, fmt.Errorf("invalid %v call: expected %v column as first argument but had: %v", name, aField.Column(), column)
+	}
+	values, err := callArgumentValues(call.Args[1:], args, argIndex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v call: %w", name, err)
+	}
+	bin := aField.Column()
+	switch name {
+	case listAppendFunction:
+		if len(values) == 0 {
+			return nil, fmt.Errorf("invalid %v call: expected at least one value", name)
+		}
+		return aField.boundList(as.ListAppendWithPolicyOp(aSet.listPolicy(), bin, aField.listItems(values)...)), nil
+	case listInsertFunction:
+		if len(values) < 2 {
+			return nil, fmt.Errorf("invalid %v call: expected index and at least one value", name)
+		}
+		index, err := asIndex(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", name, err)
+		}
+		return aField.boundList(as.ListInsertWithPolicyOp(aSet.listPolicy(), bin, index, aField.listItems(values[1:])...)), nil
+	case listRemoveValueFunction:
+		items := aField.listItems(values)
+		switch len(items) {
+		case 0:
+			return nil, fmt.Errorf("invalid %v call: expected at least one value", name)
+		case 1:
+			return []*as.Operation{as.ListRemoveByValueOp(bin, items[0], as.ListReturnTypeNone)}, nil
+		}
+		return []*as.Operation{as.ListRemoveByValueListOp(bin, items, as.ListReturnTypeNone)}, nil
+	case listTrimFunction:
+		if len(values) != 2 {
+			return nil, fmt.Errorf("invalid %v call: expected index and count", name)
+		}
+		index, err := asIndex(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", name, err)
+		}
+		count, err := asIndex(values[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", name, err)
+		}
+		return []*as.Operation{as.ListTrimOp(bin, index, count)}, nil
+	case mapPutFunction:
+		if len(values) != 2 {
+			return nil, fmt.Errorf("invalid %v call: expected key and value", name)
+		}
+		key, value, err := aField.mapEntry(values[0], values[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", name, err)
+		}
+		return []*as.Operation{as.MapPutOp(aSet.mapPolicy(as.MapWriteFlagsDefault), bin, key, value)}, nil
+	case mapIncrementFunction:
+		if len(values) != 2 {
+			return nil, fmt.Errorf("invalid %v call: expected key and delta", name)
+		}
+		key, delta, err := aField.mapEntry(values[0], values[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", name, err)
+		}
+		return []*as.Operation{as.MapIncrementOp(aSet.mapPolicy(as.MapWriteFlagsDefault), bin, key, delta)}, nil
+	case mapRemoveFunction:
+		for i := range values {
+			if values[i], err = aField.mapElement(values[i], true); err != nil {
+				return nil, fmt.Errorf("invalid %v call: %w", name, err)
+			}
+		}
+		switch len(values) {
+		case 0:
+			return nil, fmt.Errorf("invalid %v call: expected at least one key", name)
+		case 1:
+			return []*as.Operation{as.MapRemoveByKeyOp(bin, values[0], as.MapReturnType.NONE)}, nil
+		}
+		return []*as.Operation{as.MapRemoveByKeyListOp(bin, values, as.MapReturnType.NONE)}, nil
+	case hllAddFunction:
+		op, err := hllAddOperation(aField, values)
+		if err != nil {
+			return nil, err
+		}
+		return []*as.Operation{op}, nil
+	case bitSetFunction:
+		return bitSetOperations(aField, values)
+	}
+	return nil, fmt.Errorf("unsupported update function: %v, supported(LIST_APPEND, LIST_INSERT, LIST_REMOVE_VALUE, LIST_TRIM, MAP_PUT, MAP_INCREMENT, MAP_REMOVE, HLL_ADD, BIT_SET)", sqlparser.Stringify(call.X))
+}
+
+// callArgumentValues returns literal or placeholder values of function call arguments
+func callArgumentValues(nodes []node.Node, args []driver.NamedValue, argIndex *int) ([]interface{}, error) {
+	var result = make([]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		values, err := expr.NewValues(n)
+		if err != nil {
+			return nil, err
+		}
+		if len(values.X) != 1 {
+			return nil, fmt.Errorf("unsupported argument: %v", sqlparser.Stringify(n))
+		}
+		value := values.X[0].Value
+		if values.X[0].Placeholder {
+			if *argIndex >= len(args) {
+				return nil, fmt.Errorf("missing placeholder value for argument: %v", *argIndex)
+			}
+			value = args[*argIndex].Value
+			*argIndex++
+		}
+		if value, err = extractKeyValue(value); err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// mapEntry returns map function key and value arguments encoded with map field key and value types
+func (f *field) mapEntry(key, value interface{}) (interface{}, interface{}, error) {
+	key, err := f.mapElement(key, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err = f.mapElement(value, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, value, nil
+}
+
+// mapElement returns map function key or value argument encoded with map field key or value type, the same way as
+// entries of map field value written by update
+func (f *field) mapElement(value interface{}, isKey bool) (interface{}, error) {
+	mapType := f.Type
+	for mapType.Kind() == reflect.Ptr {
+		mapType = mapType.Elem()
+	}
+	if mapType.Kind() != reflect.Map {
+		return value, nil
+	}
+	elemType, kind := mapType.Elem(), "value"
+	if isKey {
+		elemType, kind = mapType.Key(), "key"
+	}
+	element := &field{Field: &xunsafe.Field{Name: f.Name, Type: elemType}, tag: &Tag{Name: f.tag.Name}}
+	encoded, err := element.ensureValueType(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v map %v: %w", f.Column(), kind, err)
+	}
+	return encoded, nil
+}
+
+// listItems expands slice values into list items unless field list holds slices
+func (f *field) listItems(values []interface{}) []interface{} {
+	var result = make([]interface{}, 0, len(values))
+	nested := f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Slice && f.Type.Elem().Elem().Kind() != reflect.Uint8
+	for _, value := range values {
+		v := reflect.ValueOf(value)
+		if nested || !v.IsValid() || v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+			result = append(result, value)
+			continue
+		}
+		for i := 0; i < v.Len(); i++ {
+			result = append(result, v.Index(i).Interface())
+		}
+	}
+	return result
+}
+
+// boundList appends ring buffer trimming to list write operation when field list is bounded with arraySize tag
+func (f *field) boundList(op *as.Operation) []*as.Operation {
+	if f.tag.ArraySize <= 0 || f.Type.Kind() != reflect.Slice || isBytesType(f.Type) {
+		return []*as.Operation{op}
+	}
+	return []*as.Operation{op, listBoundOperation(f.Column(), f.tag.ArraySize)}
+}
+
+// listBoundOperation returns operation removing all but the last size list items, the oldest items are evicted first
+func listBoundOperation(bin string, size int) *as.Operation {
+	return as.ListRemoveByIndexRangeOp(bin, -size, as.ListReturnTypeNone|as.ListReturnTypeInverted)
+}
+
+// asIndex converts list index or count argument to int
+func asIndex(value interface{}) (int, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f == float64(int(f)) {
+			return int(f), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid index value: %v", value)
+}
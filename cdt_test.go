@@ -0,0 +1,127 @@
+package aerospike
+
+import (
+	"database/sql/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlparser"
+	"github.com/viant/sqlparser/expr"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_cdtOperations(t *testing.T) {
+	type Record struct {
+		Id    int            `aerospike:"id,pk=true"`
+		Tags  []string       `aerospike:"tags"`
+		Attrs map[string]int `aerospike:"attrs"`
+		Last  []int          `aerospike:"last,arraySize=3"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		SQL         string
+		args        []interface{}
+		expectOps   int
+		expectArgs  int
+		expectErr   bool
+	}{
+		{description: "list append", SQL: "UPDATE x SET tags = LIST_APPEND(tags, ?) WHERE pk = 1", args: []interface{}{[]string{"a", "b"}}, expectOps: 1, expectArgs: 1},
+		{description: "list insert", SQL: "UPDATE x SET tags = LIST_INSERT(tags, 1, ?) WHERE pk = 1", args: []interface{}{"a"}, expectOps: 1, expectArgs: 1},
+		{description: "bounded list append", SQL: "UPDATE x SET last = LIST_APPEND(last, ?) WHERE pk = 1", args: []interface{}{7}, expectOps: 2, expectArgs: 1},
+		{description: "bounded list insert", SQL: "UPDATE x SET last = LIST_INSERT(last, 0, 1) WHERE pk = 1", expectOps: 2},
+		{description: "list remove value", SQL: "UPDATE x SET tags = LIST_REMOVE_VALUE(tags, 'a') WHERE pk = 1", expectOps: 1},
+		{description: "list trim", SQL: "UPDATE x SET tags = LIST_TRIM(tags, ?, ?) WHERE pk = 1", args: []interface{}{0, 2}, expectOps: 1, expectArgs: 2},
+		{description: "map put", SQL: "UPDATE x SET attrs = MAP_PUT(attrs, ?, ?) WHERE pk = 1", args: []interface{}{"k", 1}, expectOps: 1, expectArgs: 2},
+		{description: "map increment", SQL: "UPDATE x SET attrs = MAP_INCREMENT(attrs, 'k', 2) WHERE pk = 1", expectOps: 1},
+		{description: "map remove", SQL: "UPDATE x SET attrs = MAP_REMOVE(attrs, ?) WHERE pk = 1", args: []interface{}{"k"}, expectOps: 1, expectArgs: 1},
+		{description: "column mismatch", SQL: "UPDATE x SET tags = LIST_APPEND(attrs, ?) WHERE pk = 1", args: []interface{}{"a"}, expectErr: true},
+		{description: "invalid list trim", SQL: "UPDATE x SET tags = LIST_TRIM(tags, 1) WHERE pk = 1", expectErr: true},
+		{description: "unsupported function", SQL: "UPDATE x SET tags = LIST_SORT(tags) WHERE pk = 1", expectErr: true},
+	}
+	for _, testCase := range testCases {
+		update, err := sqlparser.ParseUpdate(testCase.SQL)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		item := update.Set[0]
+		call, ok := item.Expr.(*expr.Call)
+		if !assert.True(t, ok, testCase.description) {
+			continue
+		}
+		var args []driver.NamedValue
+		for i, arg := range testCase.args {
+			args = append(args, driver.NamedValue{Ordinal: i + 1, Value: arg})
+		}
+		argIndex := 0
+		stmt := &Statement{mapper: aMapper}
+		ops, err := stmt.cdtOperations(&set{}, aMapper.getField(sqlparser.Stringify(item.Column)), call, args, &argIndex)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectOps, len(ops), testCase.description)
+		assert.Equal(t, testCase.expectArgs, argIndex, testCase.description)
+	}
+}
+
+func Test_mapEntry(t *testing.T) {
+	type Record struct {
+		Id       int                      `aerospike:"id,pk=true"`
+		Attrs    map[string]int           `aerospike:"attrs"`
+		Timeouts map[string]time.Duration `aerospike:"timeouts"`
+		Any      map[string]interface{}   `aerospike:"any"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		column      string
+		key         interface{}
+		value       interface{}
+		expectKey   interface{}
+		expectValue interface{}
+		expectErr   bool
+	}{
+		{description: "int value", column: "attrs", key: "k", value: 2, expectKey: "k", expectValue: 2},
+		{description: "coerced number", column: "attrs", key: "k", value: int64(2), expectKey: "k", expectValue: 2},
+		{description: "converter value", column: "timeouts", key: "k", value: "1s", expectKey: "k", expectValue: int64(time.Second)},
+		{description: "invalid converter value", column: "timeouts", key: "k", value: "x", expectErr: true},
+		{description: "interface value", column: "any", key: "k", value: 1.5, expectKey: "k", expectValue: 1.5},
+		{description: "nil value", column: "attrs", key: "k", expectKey: "k"},
+	}
+	for _, testCase := range testCases {
+		key, value, err := aMapper.getField(testCase.column).mapEntry(testCase.key, testCase.value)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectKey, key, testCase.description)
+		assert.Equal(t, testCase.expectValue, value, testCase.description)
+	}
+}
+
+func Test_listItems(t *testing.T) {
+	type Record struct {
+		Id     int        `aerospike:"id,pk=true"`
+		Tags   []string   `aerospike:"tags"`
+		Groups [][]string `aerospike:"groups"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []interface{}{"a", "b", "c"}, aMapper.getField("tags").listItems([]interface{}{[]string{"a", "b"}, "c"}))
+	assert.Equal(t, []interface{}{[]string{"a", "b"}}, aMapper.getField("groups").listItems([]interface{}{[]string{"a", "b"}}))
+}
@@ -0,0 +1,389 @@
+package aerospike
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"time"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+const (
+	// defaultChunkSize represents default chunk size of chunked bins, well below default 1MB write block size
+	defaultChunkSize = 128 * 1024
+	chunkSetSuffix   = "_chunks"
+	chunkDataBin     = "data"
+
+	// chunkTTLMargin represents number of seconds chunk records outlive their main record
+	chunkTTLMargin = 24 * 60 * 60
+
+	chunkCountKey    = "chunks"
+	chunkLengthKey   = "size"
+	chunkChecksumKey = "crc32"
+	chunkVersionKey  = "version"
+)
+
+type (
+	// chunkDescriptor represents chunked bin value stored in the main record in place of the value itself
+	chunkDescriptor struct {
+		chunks   int
+		size     int
+		checksum int64
+		version  int64
+	}
+
+	// chunkWrite represents companion chunk records written ahead of the main record
+	chunkWrite struct {
+		written []*as.Key
+		stale   []*as.Key
+	}
+)
+
+// chunkSize returns chunk size of chunked field
+func (f *field) chunkSize() int {
+	if f.tag.ChunkSize > 0 {
+		return f.tag.ChunkSize
+	}
+	return defaultChunkSize
+}
+
+// chunkedFields returns fields tagged with chunked
+func (m *mapper) chunkedFields() []*field {
+	var result []*field
+	for i := range m.fields {
+		if m.fields[i].tag != nil && m.fields[i].tag.IsChunked {
+			result = append(result, &m.fields[i])
+		}
+	}
+	return result
+}
+
+// chunkSet returns name of the set holding companion chunk records
+func chunkSet(setName string) string {
+	return setName + chunkSetSuffix
+}
+
+// chunkKeys returns companion chunk record keys of the record column value version
+func (s *Statement) chunkKeys(key *as.Key, column string, descriptor *chunkDescriptor) ([]*as.Key, error) {
+	var result = make([]*as.Key, 0, descriptor.chunks)
+	for i := 0; i < descriptor.chunks; i++ {
+		chunkKey, err := as.NewKey(s.namespace, chunkSet(s.set), fmt.Sprintf("%x/%v/%v/%d", key.Digest(), column, descriptor.version, i))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, chunkKey)
+	}
+	return result, nil
+}
+
+// writeChunks writes chunked bin values into companion chunk records and replaces them in bins with chunk descriptors,
+// the main record write has to be completed with completeChunks. Chunk records expire chunkTTLMargin after the main record
+// written with write policy, previous chunk descriptors are read only if the main record write can replace existing record;
+// they are removed by update, DELETE and TRUNCATE, chunks of main records evicted by TTL expiration expire after margin
+func (s *Statement) writeChunks(ctx context.Context, key *as.Key, bins map[string]interface{}, writePolicy *as.WritePolicy) (*chunkWrite, error) {
+	fields := s.mapper.chunkedFields()
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	var columns []string
+	for _, aField := range fields {
+		if _, ok := bins[aField.Column()]; ok {
+			columns = append(columns, aField.Column())
+		}
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	var previous *as.Record
+	if !isCreateOnly(writePolicy) {
+		var err error
+		if previous, err = s.getWithCtx(ctx, nil, key, columns); err != nil && !IsKeyNotFound(err) {
+			return nil, err
+		}
+	}
+	chunkPolicy := *s.client.DefaultWritePolicy
+	chunkPolicy.Expiration = chunkExpiration(writePolicy.Expiration, previous)
+	chunkPolicy.MaxRetries = 0
+
+	ret := &chunkWrite{}
+	for _, aField := range fields {
+		column := aField.Column()
+		value, ok := bins[column]
+		if !ok {
+			continue
+		}
+		if previous != nil {
+			if descriptor, ok := asChunkDescriptor(previous.Bins[column]); ok {
+				stale, err := s.chunkKeys(key, column, descriptor)
+				if err != nil {
+					return nil, err
+				}
+				ret.stale = append(ret.stale, stale...)
+			}
+		}
+		data, err := chunkData(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunked column %v value: %w", column, err)
+		}
+		if data == nil {
+			bins[column] = nil
+			continue
+		}
+		size := aField.chunkSize()
+		descriptor := &chunkDescriptor{chunks: (len(data) + size - 1) / size, size: len(data), checksum: int64(crc32.ChecksumIEEE(data)), version: time.Now().UnixNano()}
+		keys, err := s.chunkKeys(key, column, descriptor)
+		if err != nil {
+			return nil, err
+		}
+		for i, chunkKey := range keys {
+			end := (i + 1) * size
+			if end > len(data) {
+				end = len(data)
+			}
+			if err = s.putWithCtx(ctx, &chunkPolicy, chunkKey, as.BinMap{chunkDataBin: data[i*size : end]}); err != nil {
+				return nil, s.completeChunks(ctx, ret, err)
+			}
+			ret.written = append(ret.written, chunkKey)
+		}
+		bins[column] = descriptor.bins()
+	}
+	return ret, nil
+}
+
+// isCreateOnly returns true if write policy writes only records that do not exist yet
+func isCreateOnly(writePolicy *as.WritePolicy) bool {
+	return writePolicy.RecordExistsAction == as.CREATE_ONLY || (writePolicy.GenerationPolicy == as.EXPECT_GEN_EQUAL && writePolicy.Generation == 0)
+}
+
+// chunkExpiration returns expiration of chunk records outliving main record written with expiration by chunkTTLMargin,
+// unchanged expiration is taken from the previous main record, chunks of records with namespace default TTL never expire
+func chunkExpiration(expiration uint32, previous *as.Record) uint32 {
+	if expiration == as.TTLDontUpdate && previous != nil {
+		expiration = previous.Expiration
+	}
+	switch expiration {
+	case as.TTLServerDefault, as.TTLDontExpire, as.TTLDontUpdate:
+		return as.TTLDontExpire
+	}
+	if expiration >= as.TTLDontUpdate-chunkTTLMargin {
+		return as.TTLDontExpire
+	}
+	return expiration + chunkTTLMargin
+}
+
+// renewChunks extends expiration of companion chunk records of columns not written in bins, so that they outlive
+// the main record written with expiration
+func (s *Statement) renewChunks(ctx context.Context, key *as.Key, bins map[string]interface{}, expiration uint32) error {
+	if expiration == as.TTLDontUpdate {
+		return nil
+	}
+	renew := false
+	for _, aField := range s.mapper.chunkedFields() {
+		if _, ok := bins[aField.Column()]; !ok {
+			renew = true
+		}
+	}
+	if !renew {
+		return nil
+	}
+	descriptors, err := s.chunkDescriptors(ctx, key)
+	if err != nil {
+		return err
+	}
+	chunkPolicy := *s.client.DefaultWritePolicy
+	chunkPolicy.Expiration = chunkExpiration(expiration, nil)
+	chunkPolicy.RecordExistsAction = as.UPDATE_ONLY
+	chunkPolicy.MaxRetries = 0
+	for column, descriptor := range descriptors {
+		if _, ok := bins[column]; ok {
+			continue
+		}
+		keys, err := s.chunkKeys(key, column, descriptor)
+		if err != nil {
+			return err
+		}
+		for _, chunkKey := range keys {
+			if _, opErr := s.operateWithCtx(ctx, &chunkPolicy, chunkKey, []*as.Operation{as.TouchOp()}); opErr != nil && !IsKeyNotFound(opErr) {
+				return fmt.Errorf("unable to renew chunk: %w", opErr)
+			}
+		}
+	}
+	return nil
+}
+
+// completeChunks removes stale chunk records after successful main record write, or written ones after failed write
+func (s *Statement) completeChunks(ctx context.Context, write *chunkWrite, err error) error {
+	if write == nil {
+		return err
+	}
+	keys := write.stale
+	if err != nil {
+		keys = write.written
+	}
+	for _, key := range keys {
+		if _, dErr := s.deleteWithCtx(ctx, nil, key); dErr != nil && err == nil {
+			return fmt.Errorf("unable to remove stale chunk: %w", dErr)
+		}
+	}
+	return err
+}
+
+// chunkDescriptors returns chunk descriptors of the record chunked columns by column
+func (s *Statement) chunkDescriptors(ctx context.Context, key *as.Key) (map[string]*chunkDescriptor, error) {
+	fields := s.mapper.chunkedFields()
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	columns := make([]string, 0, len(fields))
+	for _, aField := range fields {
+		columns = append(columns, aField.Column())
+	}
+	record, err := s.getWithCtx(ctx, nil, key, columns)
+	if err != nil {
+		if IsKeyNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var result = map[string]*chunkDescriptor{}
+	for _, column := range columns {
+		if descriptor, ok := asChunkDescriptor(record.Bins[column]); ok {
+			result[column] = descriptor
+		}
+	}
+	return result, nil
+}
+
+// deleteChunks removes companion chunk records of deleted record
+func (s *Statement) deleteChunks(ctx context.Context, key *as.Key, descriptors map[string]*chunkDescriptor) error {
+	for column, descriptor := range descriptors {
+		keys, err := s.chunkKeys(key, column, descriptor)
+		if err != nil {
+			return err
+		}
+		if err = s.completeChunks(ctx, &chunkWrite{stale: keys}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadChunks replaces chunk descriptors of the record with chunked values reassembled from companion chunk records
+func (s *Statement) loadChunks(ctx context.Context, record *as.Record, fields []*field) error {
+	for _, aField := range fields {
+		column := aField.Column()
+		descriptor, ok := asChunkDescriptor(record.Bins[column])
+		if !ok {
+			continue
+		}
+		if record.Key == nil {
+			return fmt.Errorf("unable to load chunked column %v: missing record key", column)
+		}
+		keys, err := s.chunkKeys(record.Key, column, descriptor)
+		if err != nil {
+			return err
+		}
+		data := make([]byte, 0, descriptor.size)
+		if len(keys) > 0 {
+			chunks, err := s.batchGetWithCtx(ctx, nil, keys, []string{chunkDataBin})
+			if err != nil && !IsKeyNotFound(err) {
+				return err
+			}
+			for i, chunk := range chunks {
+				if chunk == nil {
+					return fmt.Errorf("incomplete chunked column %v: missing chunk %v of %v", column, i, descriptor.chunks)
+				}
+				part, _ := chunk.Bins[chunkDataBin].([]byte)
+				data = append(data, part...)
+			}
+		}
+		if len(data) != descriptor.size || int64(crc32.ChecksumIEEE(data)) != descriptor.checksum {
+			return fmt.Errorf("corrupted chunked column %v: checksum mismatch", column)
+		}
+		fieldType := aField.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.String && aField.tag.Compress == "" && !aField.tag.IsEncrypted && aField.tag.Codec == "" {
+			record.Bins[column] = string(data)
+		} else {
+			record.Bins[column] = data
+		}
+	}
+	return nil
+}
+
+// truncateChunks removes companion chunk records of the statement set
+func (s *Statement) truncateChunks(ctx context.Context) error {
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return nil
+	}
+	if err = s.setRecordType(aSet); err != nil {
+		return nil
+	}
+	aMapper, err := aSet.lookupTypeBasedMapper(s.recordType)
+	if err != nil {
+		return err
+	}
+	if len(aMapper.chunkedFields()) == 0 {
+		return nil
+	}
+	return s.truncateWithCtx(ctx, nil, s.namespace, chunkSet(s.set), nil)
+}
+
+func (d *chunkDescriptor) bins() map[string]interface{} {
+	return map[string]interface{}{
+		chunkCountKey:    d.chunks,
+		chunkLengthKey:   d.size,
+		chunkChecksumKey: d.checksum,
+		chunkVersionKey:  d.version,
+	}
+}
+
+// asChunkDescriptor converts bin value to chunk descriptor
+func asChunkDescriptor(value interface{}) (*chunkDescriptor, bool) {
+	values, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil, false
+	}
+	ret := &chunkDescriptor{}
+	for key, target := range map[string]*int64{chunkCountKey: nil, chunkLengthKey: nil, chunkChecksumKey: &ret.checksum, chunkVersionKey: &ret.version} {
+		v := reflect.ValueOf(values[key])
+		if !v.IsValid() || v.Kind() < reflect.Int || v.Kind() > reflect.Int64 {
+			return nil, false
+		}
+		if target != nil {
+			*target = v.Int()
+		}
+	}
+	ret.chunks = int(reflect.ValueOf(values[chunkCountKey]).Int())
+	ret.size = int(reflect.ValueOf(values[chunkLengthKey]).Int())
+	return ret, true
+}
+
+// chunkData returns bytes of string or []byte chunked value, nil value returns nil
+func chunkData(value interface{}) ([]byte, error) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		return []byte(v.String()), nil
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return append([]byte{}, v.Bytes()...), nil
+	}
+	return nil, fmt.Errorf("unsupported type: %T, expected string or []byte", value)
+}
@@ -0,0 +1,90 @@
+package aerospike
+
+import (
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_chunkDescriptor(t *testing.T) {
+	descriptor := &chunkDescriptor{chunks: 3, size: 300, checksum: 4294967295, version: 1700000000123456789}
+	bins := map[interface{}]interface{}{}
+	for k, v := range descriptor.bins() {
+		bins[k] = v
+	}
+	actual, ok := asChunkDescriptor(bins)
+	assert.True(t, ok)
+	assert.Equal(t, descriptor, actual)
+
+	_, ok = asChunkDescriptor([]byte("raw"))
+	assert.False(t, ok)
+	_, ok = asChunkDescriptor(map[interface{}]interface{}{chunkCountKey: 1})
+	assert.False(t, ok)
+}
+
+func Test_chunkData(t *testing.T) {
+	text := "document"
+	var testCases = []struct {
+		description string
+		value       interface{}
+		expect      []byte
+		expectErr   bool
+	}{
+		{description: "string", value: "abc", expect: []byte("abc")},
+		{description: "bytes", value: []byte("abc"), expect: []byte("abc")},
+		{description: "string pointer", value: &text, expect: []byte("document")},
+		{description: "nil", value: nil},
+		{description: "nil bytes", value: []byte(nil)},
+		{description: "unsupported", value: 10, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		actual, err := chunkData(testCase.value)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func Test_ParseTag_chunked(t *testing.T) {
+	tag, err := ParseTag("body,chunked")
+	assert.Nil(t, err)
+	assert.True(t, tag.IsChunked)
+	assert.Equal(t, 0, tag.ChunkSize)
+
+	tag, err = ParseTag("body,chunked=65536")
+	assert.Nil(t, err)
+	assert.True(t, tag.IsChunked)
+	assert.Equal(t, 65536, tag.ChunkSize)
+}
+
+func Test_chunkExpiration(t *testing.T) {
+	var testCases = []struct {
+		description string
+		expiration  uint32
+		previous    *as.Record
+		expect      uint32
+	}{
+		{description: "explicit ttl", expiration: 3600, expect: 3600 + chunkTTLMargin},
+		{description: "never expires", expiration: as.TTLDontExpire, expect: as.TTLDontExpire},
+		{description: "namespace default", expiration: as.TTLServerDefault, expect: as.TTLDontExpire},
+		{description: "unchanged ttl", expiration: as.TTLDontUpdate, previous: &as.Record{Expiration: 60}, expect: 60 + chunkTTLMargin},
+		{description: "unchanged ttl of new record", expiration: as.TTLDontUpdate, expect: as.TTLDontExpire},
+		{description: "overflow", expiration: as.TTLDontUpdate - 1, expect: as.TTLDontExpire},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, chunkExpiration(testCase.expiration, testCase.previous), testCase.description)
+	}
+}
+
+func Test_isCreateOnly(t *testing.T) {
+	policy := as.NewWritePolicy(0, 0)
+	assert.False(t, isCreateOnly(policy))
+	expectGeneration(policy, 0)
+	assert.True(t, isCreateOnly(policy))
+	expectGeneration(policy, 3)
+	assert.False(t, isCreateOnly(policy))
+	assert.True(t, isCreateOnly(&as.WritePolicy{RecordExistsAction: as.CREATE_ONLY}))
+}
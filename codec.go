@@ -0,0 +1,120 @@
+package aerospike
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Codec serializes field value into a single blob bin
+type Codec interface {
+	// Encode encodes value
+	Encode(value interface{}) ([]byte, error)
+	// Decode decodes data into target pointer
+	Decode(data []byte, target interface{}) error
+}
+
+type (
+	jsonCodec struct{}
+	gobCodec  struct{}
+)
+
+var codecs = struct {
+	mux    sync.RWMutex
+	codecs map[string]Codec
+}{codecs: map[string]Codec{"json": jsonCodec{}, "gob": gobCodec{}}}
+
+// RegisterCodec registers codec used by fields tagged with codec=name, codecs other than json and gob
+// (e.g. msgpack) have to be registered before sets using them
+func RegisterCodec(name string, codec Codec) {
+	codecs.mux.Lock()
+	defer codecs.mux.Unlock()
+	codecs.codecs[strings.ToLower(name)] = codec
+}
+
+func lookupCodec(name string) (Codec, error) {
+	codecs.mux.RLock()
+	defer codecs.mux.RUnlock()
+	codec, ok := codecs.codecs[strings.ToLower(name)]
+	if !ok {
+		var supported []string
+		for key := range codecs.codecs {
+			supported = append(supported, key)
+		}
+		sort.Strings(supported)
+		return nil, fmt.Errorf("unsupported codec: %v, supported(%v)", name, strings.Join(supported, ", "))
+	}
+	return codec, nil
+}
+
+// codecError returns error naming set and column of the field with misconfigured codec
+func codecError(setName string, aField *field, err error) error {
+	return fmt.Errorf("invalid %v codec of %v.%v: %w", aField.tag.Codec, setName, aField.Column(), err)
+}
+
+// encode encodes field value with the field codec, nil values are stored as nil
+func (f *field) encode(value interface{}) (interface{}, error) {
+	if iFacePtr, ok := value.(*interface{}); ok && iFacePtr != nil {
+		value = *iFacePtr
+	}
+	if v := reflect.ValueOf(value); !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil, nil
+	}
+	codec, err := lookupCodec(f.tag.Codec)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Encode(value)
+}
+
+// decode decodes bin value into field type value with the field codec
+func (f *field) decode(value interface{}) (interface{}, error) {
+	if value == nil {
+		return reflect.Zero(f.Type).Interface(), nil
+	}
+	data, ok := value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("expected []byte but had %T", value)
+	}
+	codec, err := lookupCodec(f.tag.Codec)
+	if err != nil {
+		return nil, err
+	}
+	targetType := f.Type
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+	target := reflect.New(targetType)
+	if err = codec.Decode(data, target.Interface()); err != nil {
+		return nil, err
+	}
+	if f.Type.Kind() == reflect.Ptr {
+		return target.Interface(), nil
+	}
+	return target.Elem().Interface(), nil
+}
+
+func (jsonCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec) Decode(data []byte, target interface{}) error {
+	return json.Unmarshal(data, target)
+}
+
+func (gobCodec) Encode(value interface{}) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := gob.NewEncoder(buffer).Encode(value); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (gobCodec) Decode(data []byte, target interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(target)
+}
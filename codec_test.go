@@ -0,0 +1,86 @@
+package aerospike
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type testSettings struct {
+	Theme   string            `json:"theme"`
+	Limits  []int             `json:"limits"`
+	Labels  map[string]string `json:"labels"`
+	Ratio   float64           `json:"ratio"`
+	Enabled bool              `json:"enabled"`
+	Big     int64             `json:"big"`
+	Data    []byte            `json:"data"`
+}
+
+func Test_codec(t *testing.T) {
+	type Record struct {
+		Id         int           `aerospike:"id,pk=true"`
+		JSON       testSettings  `aerospike:"json,codec=json"`
+		Gob        *testSettings `aerospike:"gob,codec=gob"`
+		Compressed []string      `aerospike:"compressed,codec=json,compress=gzip"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	settings := testSettings{Theme: "dark", Limits: []int{1, -40, 70000}, Labels: map[string]string{"a": "b"}, Ratio: 0.25, Enabled: true, Big: 1 << 40, Data: []byte{0, 1}}
+	var testCases = []struct {
+		description string
+		column      string
+		value       interface{}
+		expect      interface{}
+	}{
+		{description: "json", column: "json", value: settings, expect: settings},
+		{description: "gob pointer", column: "gob", value: &settings, expect: &settings},
+		{description: "nil pointer", column: "gob", value: (*testSettings)(nil), expect: (*testSettings)(nil)},
+		{description: "json compressed", column: "compressed", value: []string{"x", "y"}, expect: []string{"x", "y"}},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		bin, err := aField.ensureValidValueType(testCase.value)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if bin == nil {
+			assert.Nil(t, testCase.expect.(*testSettings), testCase.description)
+			continue
+		}
+		assert.IsType(t, []byte{}, bin, testCase.description)
+		if aField.tag.Compress != "" {
+			if bin, err = aField.decompress(bin); !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+		}
+		actual, err := aField.decodeValue(bin)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+
+	_, err = aMapper.getField("json").decodeValue("not a blob")
+	if assert.NotNil(t, err) {
+		assert.EqualError(t, codecError("users", aMapper.getField("json"), err), "invalid json codec of users.json: expected []byte but had string")
+	}
+
+	type Unknown struct {
+		Id       int          `aerospike:"id,pk=true"`
+		Settings testSettings `aerospike:"settings,codec=msgpack"`
+	}
+	_, err = newTypeBasedMapper(reflect.TypeOf(Unknown{}))
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unsupported codec: msgpack, supported(gob, json)")
+	}
+	RegisterCodec("msgpack", jsonCodec{})
+	defer func() {
+		codecs.mux.Lock()
+		delete(codecs.codecs, "msgpack")
+		codecs.mux.Unlock()
+	}()
+	_, err = newTypeBasedMapper(reflect.TypeOf(Unknown{}))
+	assert.Nil(t, err, "registered codec")
+}
@@ -0,0 +1,142 @@
+package aerospike
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// compressed bin values start with compressHeader: magic bytes, format version and codec id.
+// Adding compress tag to an existing field needs no migration: blobs and strings without the header (written before
+// the tag was added) are read back as is and stored compressed on the next write. Legacy blobs starting with
+// the magic bytes would be misread as compressed and have to be rewritten before the tag is added
+var compressMagic = []byte{0xAE, 0xC0}
+
+const compressVersion byte = 1
+
+// compression codec ids stored as the last header byte of compressed bin value, ids must never be reused
+const (
+	codecRaw   byte = 0
+	codecGzip  byte = 1
+	codecZlib  byte = 2
+	codecFlate byte = 3
+)
+
+// compressHeaderLen represents length of compressed bin value header
+const compressHeaderLen = 4
+
+// compressHeader returns compressed bin value header of the codec
+func compressHeader(codec byte) []byte {
+	return append(append(make([]byte, 0, compressHeaderLen), compressMagic...), compressVersion, codec)
+}
+
+// isCompressed returns true if blob starts with compressed value magic bytes
+func isCompressed(data []byte) bool {
+	return len(data) >= compressHeaderLen && bytes.HasPrefix(data, compressMagic)
+}
+
+// compressionCodecID returns header byte of compress tag codec
+func compressionCodecID(codec string) (byte, error) {
+	switch codec {
+	case "gzip":
+		return codecGzip, nil
+	case "zlib":
+		return codecZlib, nil
+	case "flate", "deflate":
+		return codecFlate, nil
+	}
+	return 0, fmt.Errorf("unsupported compress codec: %v, supported(gzip, zlib, flate)", codec)
+}
+
+// compress compresses string or []byte value with field codec, the value is stored as blob prefixed with compressed value header,
+// values that do not shrink are stored raw with codecRaw header
+func (f *field) compress(value interface{}) (interface{}, error) {
+	data, err := chunkData(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compress %v: %w", f.Column(), err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	codec, err := compressionCodecID(f.tag.Compress)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	buffer.Write(compressHeader(codec))
+	var writer io.WriteCloser
+	switch codec {
+	case codecGzip:
+		writer = gzip.NewWriter(&buffer)
+	case codecZlib:
+		writer = zlib.NewWriter(&buffer)
+	default:
+		if writer, err = flate.NewWriter(&buffer, flate.DefaultCompression); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = writer.Write(data); err != nil {
+		return nil, fmt.Errorf("unable to compress %v: %w", f.Column(), err)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("unable to compress %v: %w", f.Column(), err)
+	}
+	if buffer.Len() > len(data)+compressHeaderLen {
+		return append(compressHeader(codecRaw), data...), nil
+	}
+	return buffer.Bytes(), nil
+}
+
+// decompress decodes compressed blob according to its header codec, values stored before compression was enabled
+// (strings and blobs without compressed value header) are returned as is
+func (f *field) decompress(value interface{}) (interface{}, error) {
+	data, ok := value.([]byte)
+	if !ok || len(data) == 0 {
+		return value, nil
+	}
+	if !isCompressed(data) {
+		return f.decompressed(data), nil
+	}
+	if version := data[len(compressMagic)]; version != compressVersion {
+		return nil, fmt.Errorf("unable to decompress %v: unsupported format version: %v", f.Column(), version)
+	}
+	var reader io.Reader
+	var err error
+	codec := data[compressHeaderLen-1]
+	payload := bytes.NewReader(data[compressHeaderLen:])
+	switch codec {
+	case codecRaw:
+		return f.decompressed(data[compressHeaderLen:]), nil
+	case codecGzip:
+		reader, err = gzip.NewReader(payload)
+	case codecZlib:
+		reader, err = zlib.NewReader(payload)
+	case codecFlate:
+		reader = flate.NewReader(payload)
+	default:
+		return nil, fmt.Errorf("unable to decompress %v: unknown codec: %v", f.Column(), codec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress %v: %w", f.Column(), err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress %v: %w", f.Column(), err)
+	}
+	return f.decompressed(decompressed), nil
+}
+
+func (f *field) decompressed(data []byte) interface{} {
+	fieldType := f.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.String && f.tag.Codec == "" {
+		return string(data)
+	}
+	return data
+}
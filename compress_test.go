@@ -0,0 +1,109 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/x"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_fieldCompress(t *testing.T) {
+	type Record struct {
+		Id    int    `aerospike:"id,pk=true"`
+		Gzip  string `aerospike:"gzip,compress=gzip"`
+		Zlib  []byte `aerospike:"zlib,compress=zlib"`
+		Flate string `aerospike:"flate,compress=flate"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	text := strings.Repeat("{\"name\":\"compressible json\"}", 20)
+	var testCases = []struct {
+		description string
+		column      string
+		value       interface{}
+		expect      interface{}
+		expectCodec byte
+	}{
+		{description: "gzip string", column: "gzip", value: text, expect: text, expectCodec: codecGzip},
+		{description: "zlib bytes", column: "zlib", value: []byte(text), expect: []byte(text), expectCodec: codecZlib},
+		{description: "flate string", column: "flate", value: text, expect: text, expectCodec: codecFlate},
+		{description: "incompressible value stored raw", column: "gzip", value: "ab", expect: "ab", expectCodec: codecRaw},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		compressed, err := aField.ensureValidValueType(testCase.value)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		data, ok := compressed.([]byte)
+		if !assert.True(t, ok, testCase.description) {
+			continue
+		}
+		assert.Equal(t, compressHeader(testCase.expectCodec), data[:compressHeaderLen], testCase.description)
+		actual, err := aField.decompress(compressed)
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+
+	actual, err := aMapper.getField("gzip").decompress("stored before compression")
+	assert.Nil(t, err)
+	assert.Equal(t, "stored before compression", actual)
+	actual, err = aMapper.getField("zlib").decompress([]byte{codecGzip, 'a', 'b'})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{codecGzip, 'a', 'b'}, actual, "blob stored before compression")
+	actual, err = aMapper.getField("gzip").decompress([]byte("stored before compression"))
+	assert.Nil(t, err)
+	assert.Equal(t, "stored before compression", actual, "string blob stored before compression")
+	_, err = aMapper.getField("gzip").decompress(append(compressMagic, compressVersion, 99, 1, 2))
+	assert.NotNil(t, err, "unknown codec")
+	_, err = aMapper.getField("gzip").decompress(append(compressMagic, 9, codecGzip, 1, 2))
+	assert.NotNil(t, err, "unknown format version")
+	_, err = aMapper.getField("gzip").ensureValidValueType(10)
+	assert.NotNil(t, err)
+}
+
+func Test_ParseTag_compress(t *testing.T) {
+	tag, err := ParseTag("body,compress=GZIP")
+	assert.Nil(t, err)
+	assert.Equal(t, "gzip", tag.Compress)
+
+	_, err = ParseTag("body,compress=snappy")
+	assert.NotNil(t, err)
+}
+
+func Test_compressedColumnViolations(t *testing.T) {
+	type Counter struct {
+		Id    int    `aerospike:"id,pk=true"`
+		Body  string `aerospike:"body,compress=gzip"`
+		Total int    `aerospike:"total,compress=gzip"`
+		Price string `aerospike:"price,scale=2,compress=gzip"`
+	}
+	err := newRegistry().Register(&set{xType: x.NewType(reflect.TypeOf(Counter{}), x.WithName("counters"))})
+	definitionErr := &DefinitionError{}
+	if assert.True(t, errors.As(err, &definitionErr)) {
+		assert.Len(t, definitionErr.Violations, 2)
+	}
+
+	type Document struct {
+		Id   int    `aerospike:"id,pk=true"`
+		Body string `aerospike:"body,compress=gzip"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Document{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	stmt := &Statement{mapper: aMapper, recordType: reflect.TypeOf(Document{})}
+	if !assert.Nil(t, stmt.prepareUpdate("UPDATE documents SET body = body + ? WHERE pk = ?")) {
+		return
+	}
+	err = stmt.handleUpdate(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: "x"}, {Ordinal: 2, Value: 1}})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unsupported + operator on compressed column body")
+	}
+}
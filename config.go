@@ -20,6 +20,9 @@ type Config struct {
 	Values                url.Values
 	disableCache          bool
 	insertCacheMaxEntries int
+	schema                string
+	inferSchema           bool
+	inferSampleSize       int
 
 	// expiry options
 	/*
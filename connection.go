@@ -13,7 +13,7 @@ import (
 type connection struct {
 	cfg          *Config
 	client       *as.Client
-	sets         *registry
+	sets         *Registry
 	writeLimiter *limiter
 	insertCache  *lru.Cache // holds *insert.Statement values
 	mu           sync.RWMutex
@@ -26,12 +26,7 @@ func (c *connection) Prepare(query string) (driver.Stmt, error) {
 
 // PrepareContext returns a prepared statement, bound to this connection.
 func (c *connection) PrepareContext(ctx context.Context, SQL string) (driver.Stmt, error) {
-	kind := sqlparser.ParseKind(SQL)
-	err := c.sets.Merge(globalSets)
-	if err != nil {
-		return nil, err
-	}
-
+	kind := parseKind(SQL)
 	stmt := &Statement{
 		SQL:          SQL,
 		kind:         kind,
@@ -65,6 +60,16 @@ func (c *connection) PrepareContext(ctx context.Context, SQL string) (driver.Stm
 			return nil, err
 		}
 	case sqlparser.KindRegisterSet:
+	case kindUnregisterSet:
+		if err := stmt.prepareUnregisterSet(SQL); err != nil {
+			return nil, err
+		}
+		return stmt, nil
+	case kindAnalyzeSet:
+		if err := stmt.prepareAnalyzeSet(SQL); err != nil {
+			return nil, err
+		}
+		return stmt, nil
 	case sqlparser.KindCreateIndex:
 		if err := stmt.prepareCreateIndex(SQL); err != nil {
 			return nil, err
@@ -79,13 +84,24 @@ func (c *connection) PrepareContext(ctx context.Context, SQL string) (driver.Stm
 		return nil, fmt.Errorf("unsupported kind: %v for DDL: %v", kind, SQL)
 	}
 
-	if err := stmt.setTypeBasedMapper(); err != nil {
+	if err := stmt.setTypeBasedMapper(ctx); err != nil {
 		return nil, err
 	}
 
 	return stmt, nil
 }
 
+// parseKind returns SQL kind, UNREGISTER SET and ANALYZE SET are not recognized by sqlparser
+func parseKind(SQL string) sqlparser.Kind {
+	switch {
+	case unregisterSetExpr.MatchString(SQL):
+		return kindUnregisterSet
+	case analyzeSetExpr.MatchString(SQL):
+		return kindAnalyzeSet
+	}
+	return sqlparser.ParseKind(SQL)
+}
+
 // Ping pings server
 func (c *connection) Ping(ctx context.Context) error {
 	return nil
@@ -119,7 +135,7 @@ func (c *connection) IsValid() bool {
 	return true
 }
 
-func newConnection(cfg *Config, client *as.Client, limiter *limiter) (*connection, error) {
+func newConnection(cfg *Config, client *as.Client, limiter *limiter, sets *Registry) (*connection, error) {
 	var err error
 	var insCache *lru.Cache
 	if !cfg.disableCache {
@@ -132,7 +148,7 @@ func newConnection(cfg *Config, client *as.Client, limiter *limiter) (*connectio
 	ret := &connection{
 		cfg:          cfg,
 		client:       client,
-		sets:         newRegistry(),
+		sets:         sets,
 		writeLimiter: limiter,
 		insertCache:  insCache,
 	}
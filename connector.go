@@ -0,0 +1,62 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+)
+
+type (
+	// Connector represents database/sql driver.Connector, use it with sql.OpenDB
+	Connector struct {
+		dsn      string
+		registry *Registry
+		mux      sync.Mutex
+		conn     *connection
+	}
+
+	// ConnectorOption represents connector option
+	ConnectorOption func(c *Connector)
+)
+
+// WithRegistry sets registry of sql.DB sets, sets registered with REGISTER SET or RegisterSet on the registry
+// are visible only to the sql.DB opened with the connector
+func WithRegistry(registry *Registry) ConnectorOption {
+	return func(c *Connector) {
+		c.registry = registry
+	}
+}
+
+// NewConnector creates a connector
+func NewConnector(dsn string, options ...ConnectorOption) *Connector {
+	ret := &Connector{dsn: dsn}
+	for _, option := range options {
+		option(ret)
+	}
+	return ret
+}
+
+// Connect returns a connection, connections of connector without registry are shared by DSN
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.registry == nil {
+		return Driver{}.Open(c.dsn)
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.conn != nil {
+		return c.conn, nil
+	}
+	conn, err := openConnection(c.dsn, c.registry)
+	if err != nil {
+		return nil, err
+	}
+	if !conn.cfg.disablePool {
+		c.conn = conn
+	}
+	return conn, nil
+}
+
+// Driver returns driver
+func (c *Connector) Driver() driver.Driver {
+	return &Driver{}
+}
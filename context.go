@@ -73,6 +73,15 @@ func (s *Statement) operateWithCtx(ctx context.Context, base *as.WritePolicy, ke
 	return s.client.Operate(policy, key, ops...)
 }
 
+// deleteWithCtx wraps Delete with context-based timeout.
+func (s *Statement) deleteWithCtx(ctx context.Context, base *as.WritePolicy, key *as.Key) (bool, as.Error) {
+	if base == nil {
+		base = s.client.DefaultWritePolicy
+	}
+	policy := clonePolicyWithContext(ctx, base).(*as.WritePolicy)
+	return s.client.Delete(policy, key)
+}
+
 // getWithCtx performs a Get operation with context-based timeout support.
 func (s *Statement) getWithCtx(ctx context.Context, basePolicy *as.BasePolicy, key *as.Key, binNames []string) (*as.Record, error) {
 	if basePolicy == nil {
@@ -0,0 +1,391 @@
+package aerospike
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// ToBinConverter converts field value to bin value
+	ToBinConverter func(value interface{}) (interface{}, error)
+	// FromBinConverter converts bin value to field value of the registered type
+	FromBinConverter func(value interface{}) (interface{}, error)
+
+	converter struct {
+		goType  reflect.Type
+		toBin   ToBinConverter
+		fromBin FromBinConverter
+	}
+)
+
+var (
+	durationType   = reflect.TypeOf(time.Duration(0))
+	uuidType       = reflect.TypeOf([16]byte{})
+	bigIntType     = reflect.TypeOf(big.Int{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	valuerType     = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType    = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+var converters = struct {
+	mux      sync.RWMutex
+	registry map[reflect.Type]*converter
+}{registry: map[reflect.Type]*converter{}}
+
+// RegisterConverter registers converter of go type (or pointer to it) fields, used on both write and read path,
+// registered converter takes precedence over built-in conversion
+func RegisterConverter(goType reflect.Type, toBin ToBinConverter, fromBin FromBinConverter) {
+	converters.mux.Lock()
+	defer converters.mux.Unlock()
+	converters.registry[goType] = &converter{goType: goType, toBin: toBin, fromBin: fromBin}
+}
+
+func init() {
+	RegisterConverter(durationType, durationToBin, durationFromBin)
+	RegisterConverter(uuidType, uuidToBin, uuidFromBin)
+	RegisterConverter(bigIntType, bigIntToBin, bigIntFromBin)
+	RegisterConverter(rawMessageType, rawMessageToBin, rawMessageFromBin)
+}
+
+// lookupConverter returns converter of the field type, pointer field types use converter of the element type,
+// types implementing driver.Valuer or sql.Scanner use interface based converter
+func lookupConverter(fieldType reflect.Type) *converter {
+	goType := fieldType
+	if goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+	converters.mux.RLock()
+	ret, ok := converters.registry[goType]
+	converters.mux.RUnlock()
+	if ok {
+		return ret
+	}
+	isValuer := goType.Implements(valuerType) || reflect.PtrTo(goType).Implements(valuerType)
+	isScanner := reflect.PtrTo(goType).Implements(scannerType)
+	if !isValuer && !isScanner {
+		return nil
+	}
+	ret = &converter{goType: goType}
+	if isValuer {
+		ret.toBin = valuerToBin
+	}
+	if isScanner {
+		ret.fromBin = func(value interface{}) (interface{}, error) {
+			target := reflect.New(goType)
+			if err := target.Interface().(sql.Scanner).Scan(value); err != nil {
+				return nil, err
+			}
+			return target.Elem().Interface(), nil
+		}
+	}
+	return ret
+}
+
+// convertToBin converts field value with the converter, nil values are passed as nil
+func (c *converter) convertToBin(value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if c.toBin == nil {
+		return v.Interface(), nil
+	}
+	return c.toBin(v.Interface())
+}
+
+// convertFromBin converts bin value to field type value with the converter
+func (c *converter) convertFromBin(value interface{}, fieldType reflect.Type) (interface{}, error) {
+	if c.fromBin == nil {
+		return nil, fmt.Errorf("missing %v bin converter", c.goType)
+	}
+	converted, err := c.fromBin(value)
+	if err != nil {
+		return nil, err
+	}
+	result := reflect.ValueOf(converted)
+	if !result.IsValid() {
+		return reflect.Zero(fieldType).Interface(), nil
+	}
+	if result.Type() != c.goType && result.Type().ConvertibleTo(c.goType) {
+		result = result.Convert(c.goType)
+	}
+	if fieldType.Kind() == reflect.Ptr && result.Type() != fieldType {
+		ptr := reflect.New(result.Type())
+		ptr.Elem().Set(result)
+		result = ptr
+	}
+	return result.Interface(), nil
+}
+
+func valuerToBin(value interface{}) (interface{}, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	ptr := reflect.New(reflect.TypeOf(value))
+	ptr.Elem().Set(reflect.ValueOf(value))
+	if valuer, ok := ptr.Interface().(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return value, nil
+}
+
+func durationToBin(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case time.Duration:
+		return int64(actual), nil
+	case string:
+		d, err := time.ParseDuration(actual)
+		if err != nil {
+			return nil, err
+		}
+		return int64(d), nil
+	}
+	return coerceNumber(value, int64Type)
+}
+
+func durationFromBin(value interface{}) (interface{}, error) {
+	if text, ok := value.(string); ok {
+		return time.ParseDuration(text)
+	}
+	v, err := coerceNumber(value, int64Type)
+	if err != nil {
+		return nil, err
+	}
+	return time.Duration(v.(int64)), nil
+}
+
+func uuidToBin(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case [16]byte:
+		return actual[:], nil
+	case string:
+		uuid, err := parseUUID(actual)
+		if err != nil {
+			return nil, err
+		}
+		return uuid[:], nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Array && v.Len() == 16 && v.Type().Elem().Kind() == reflect.Uint8 {
+		ret := make([]byte, 16)
+		reflect.Copy(reflect.ValueOf(ret), v)
+		return ret, nil
+	}
+	return nil, fmt.Errorf("unsupported UUID value type: %T", value)
+}
+
+func uuidFromBin(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case []byte:
+		var ret [16]byte
+		if len(actual) != len(ret) {
+			return nil, fmt.Errorf("invalid UUID length: %v", len(actual))
+		}
+		copy(ret[:], actual)
+		return ret, nil
+	case string:
+		return parseUUID(actual)
+	}
+	return nil, fmt.Errorf("unsupported UUID bin type: %T", value)
+}
+
+func parseUUID(text string) ([16]byte, error) {
+	var ret [16]byte
+	data, err := hex.DecodeString(strings.ReplaceAll(text, "-", ""))
+	if err != nil {
+		return ret, fmt.Errorf("invalid UUID %v: %w", text, err)
+	}
+	if len(data) != len(ret) {
+		return ret, fmt.Errorf("invalid UUID %v", text)
+	}
+	copy(ret[:], data)
+	return ret, nil
+}
+
+// bigIntToBin stores big.Int as decimal string, as it may exceed 64-bit integer bin range
+func bigIntToBin(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case big.Int:
+		return actual.String(), nil
+	case string:
+		if _, ok := new(big.Int).SetString(actual, 10); !ok {
+			return nil, fmt.Errorf("invalid big.Int value: %v", actual)
+		}
+		return actual, nil
+	}
+	v, err := coerceNumber(value, int64Type)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(v.(int64)).String(), nil
+}
+
+func bigIntFromBin(value interface{}) (interface{}, error) {
+	ret := new(big.Int)
+	switch actual := value.(type) {
+	case string:
+		if _, ok := ret.SetString(actual, 10); !ok {
+			return nil, fmt.Errorf("invalid big.Int value: %v", actual)
+		}
+		return *ret, nil
+	case []byte:
+		return *ret.SetBytes(actual), nil
+	}
+	v, err := coerceNumber(value, int64Type)
+	if err != nil {
+		return nil, err
+	}
+	return *ret.SetInt64(v.(int64)), nil
+}
+
+func rawMessageToBin(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case json.RawMessage:
+		if actual == nil {
+			return nil, nil
+		}
+		return []byte(actual), nil
+	case []byte:
+		return actual, nil
+	case string:
+		return []byte(actual), nil
+	}
+	return json.Marshal(value)
+}
+
+func rawMessageFromBin(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case []byte:
+		return json.RawMessage(append([]byte(nil), actual...)), nil
+	case string:
+		return json.RawMessage(actual), nil
+	}
+	data, err := json.Marshal(normalizeJSONValue(value))
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// normalizeJSONValue converts aerospike map[interface{}]interface{} values to json marshalable maps
+func normalizeJSONValue(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			ret[fmt.Sprint(k)] = normalizeJSONValue(v)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(actual))
+		for i, v := range actual {
+			ret[i] = normalizeJSONValue(v)
+		}
+		return ret
+	}
+	return value
+}
+
+var (
+	int64Type = reflect.TypeOf(int64(0))
+	kindTypes = map[reflect.Kind]reflect.Type{
+		reflect.Int: reflect.TypeOf(0), reflect.Int8: reflect.TypeOf(int8(0)), reflect.Int16: reflect.TypeOf(int16(0)), reflect.Int32: reflect.TypeOf(int32(0)), reflect.Int64: int64Type,
+		reflect.Uint: reflect.TypeOf(uint(0)), reflect.Uint8: reflect.TypeOf(uint8(0)), reflect.Uint16: reflect.TypeOf(uint16(0)), reflect.Uint32: reflect.TypeOf(uint32(0)), reflect.Uint64: reflect.TypeOf(uint64(0)),
+		reflect.Float32: reflect.TypeOf(float32(0)), reflect.Float64: reflect.TypeOf(float64(0)),
+	}
+)
+
+func isNumericKind(kind reflect.Kind) bool {
+	_, ok := kindTypes[kind]
+	return ok
+}
+
+// coerceNumber converts numeric value to target numeric type, returns error when value overflows target type,
+// or float value with fraction is converted to integer type
+func coerceNumber(value interface{}, target reflect.Type) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || !isNumericKind(v.Kind()) || !isNumericKind(target.Kind()) {
+		return nil, fmt.Errorf("unable to ensure valid type: can't convert value %v (%T) to type %v", value, value, target)
+	}
+	overflow := fmt.Errorf("unable to ensure valid type: value %v overflows type %v", value, target)
+	zero := reflect.Zero(target)
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = v.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if v.Uint() > math.MaxInt64 {
+				return nil, overflow
+			}
+			i = int64(v.Uint())
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("unable to ensure valid type: can't convert value %v (%v) to type %v without loss", value, v.Kind(), target)
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, overflow
+			}
+			i = int64(f)
+		}
+		if zero.OverflowInt(i) {
+			return nil, overflow
+		}
+		return reflect.ValueOf(i).Convert(target).Interface(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.Int() < 0 {
+				return nil, overflow
+			}
+			u = uint64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u = v.Uint()
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("unable to ensure valid type: can't convert value %v (%v) to type %v without loss", value, v.Kind(), target)
+			}
+			if f < 0 || f >= math.MaxUint64 {
+				return nil, overflow
+			}
+			u = uint64(f)
+		}
+		if zero.OverflowUint(u) {
+			return nil, overflow
+		}
+		return reflect.ValueOf(u).Convert(target).Interface(), nil
+	default:
+		var f float64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			f = float64(v.Uint())
+		default:
+			f = v.Float()
+		}
+		if !math.IsInf(f, 0) && !math.IsNaN(f) && zero.OverflowFloat(f) {
+			return nil, overflow
+		}
+		return reflect.ValueOf(f).Convert(target).Interface(), nil
+	}
+}
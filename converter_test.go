@@ -0,0 +1,193 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_coerceNumber(t *testing.T) {
+	var testCases = []struct {
+		description string
+		value       interface{}
+		target      reflect.Type
+		expect      interface{}
+		expectErr   bool
+	}{
+		{description: "int to float64", value: 3, target: reflect.TypeOf(float64(0)), expect: float64(3)},
+		{description: "uint to float32", value: uint(3), target: reflect.TypeOf(float32(0)), expect: float32(3)},
+		{description: "integral float to int", value: 3.0, target: reflect.TypeOf(0), expect: 3},
+		{description: "float with fraction to int", value: 3.5, target: reflect.TypeOf(0), expectErr: true},
+		{description: "int64 to int8", value: int64(127), target: reflect.TypeOf(int8(0)), expect: int8(127)},
+		{description: "int64 to int8 overflow", value: int64(128), target: reflect.TypeOf(int8(0)), expectErr: true},
+		{description: "negative int to uint", value: -1, target: reflect.TypeOf(uint(0)), expectErr: true},
+		{description: "uint64 to int64 overflow", value: uint64(math.MaxUint64), target: reflect.TypeOf(int64(0)), expectErr: true},
+		{description: "float64 to float32 overflow", value: math.MaxFloat64, target: reflect.TypeOf(float32(0)), expectErr: true},
+		{description: "string", value: "1", target: reflect.TypeOf(0), expectErr: true},
+	}
+	for _, testCase := range testCases {
+		actual, err := coerceNumber(testCase.value, testCase.target)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+type testTemperature struct {
+	Celsius float64
+}
+
+func Test_converters(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(testTemperature{}), func(value interface{}) (interface{}, error) {
+		return fmt.Sprintf("%.1fC", value.(testTemperature).Celsius), nil
+	}, func(value interface{}) (interface{}, error) {
+		var ret testTemperature
+		_, err := fmt.Sscanf(strings.TrimSuffix(value.(string), "C"), "%f", &ret.Celsius)
+		return ret, err
+	})
+	type Record struct {
+		Id       int             `aerospike:"id,pk=true"`
+		Timeout  time.Duration   `aerospike:"timeout"`
+		UUID     [16]byte        `aerospike:"uuid"`
+		Balance  *big.Int        `aerospike:"balance"`
+		Payload  json.RawMessage `aerospike:"payload"`
+		Nickname sql.NullString  `aerospike:"nickname"`
+		Temp     testTemperature `aerospike:"temp"`
+		Small    int8            `aerospike:"small"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	uuid := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	balance, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	var testCases = []struct {
+		description string
+		column      string
+		value       interface{}
+		expectBin   interface{}
+		expect      interface{}
+		expectErr   bool
+	}{
+		{description: "duration", column: "timeout", value: 3 * time.Second, expectBin: int64(3 * time.Second), expect: 3 * time.Second},
+		{description: "duration from text", column: "timeout", value: "1m", expectBin: int64(time.Minute), expect: time.Minute},
+		{description: "uuid", column: "uuid", value: uuid, expectBin: uuid[:], expect: uuid},
+		{description: "uuid from text", column: "uuid", value: "123e4567-e89b-12d3-a456-426614174000", expectBin: uuid[:], expect: uuid},
+		{description: "big int", column: "balance", value: balance, expectBin: balance.String(), expect: balance},
+		{description: "raw message", column: "payload", value: json.RawMessage(`{"a":1}`), expectBin: []byte(`{"a":1}`), expect: json.RawMessage(`{"a":1}`)},
+		{description: "valuer and scanner", column: "nickname", value: sql.NullString{String: "bob", Valid: true}, expectBin: "bob", expect: sql.NullString{String: "bob", Valid: true}},
+		{description: "null valuer", column: "nickname", value: sql.NullString{}, expectBin: nil, expect: sql.NullString{}},
+		{description: "registered converter", column: "temp", value: testTemperature{Celsius: 21.5}, expectBin: "21.5C", expect: testTemperature{Celsius: 21.5}},
+		{description: "numeric coercion", column: "small", value: 12.0, expectBin: 12},
+		{description: "numeric overflow", column: "small", value: 300, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		bin, err := aField.ensureValidValueType(testCase.value)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if aField.Type.Kind() == reflect.Int8 {
+			assert.EqualValues(t, testCase.expectBin, bin, testCase.description)
+			continue
+		}
+		assert.Equal(t, testCase.expectBin, bin, testCase.description)
+		conv := lookupConverter(aField.Type)
+		if !assert.NotNil(t, conv, testCase.description) {
+			continue
+		}
+		actual, err := conv.convertFromBin(bin, aField.Type)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+// testRowsConnector serves driver rows to database/sql, so that converted values are scanned with sql.Rows
+type testRowsConnector struct {
+	rows driver.Rows
+}
+
+func (c *testRowsConnector) Connect(context.Context) (driver.Conn, error) {
+	return &testRowsConn{rows: c.rows}, nil
+}
+func (c *testRowsConnector) Driver() driver.Driver { return &Driver{} }
+
+type testRowsConn struct {
+	rows driver.Rows
+}
+
+func (c *testRowsConn) Prepare(string) (driver.Stmt, error) { return nil, fmt.Errorf("not supported") }
+func (c *testRowsConn) Close() error                        { return nil }
+func (c *testRowsConn) Begin() (driver.Tx, error)           { return nil, fmt.Errorf("not supported") }
+func (c *testRowsConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return c.rows, nil
+}
+
+func Test_convertersScan(t *testing.T) {
+	type Record struct {
+		Id       int             `aerospike:"id,pk=true"`
+		Timeout  time.Duration   `aerospike:"timeout"`
+		UUID     [16]byte        `aerospike:"uuid"`
+		Balance  *big.Int        `aerospike:"balance"`
+		Payload  json.RawMessage `aerospike:"payload"`
+		Nickname sql.NullString  `aerospike:"nickname"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	uuid := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	stmt := &Statement{recordType: reflect.TypeOf(Record{}), set: "records", sets: newRegistry()}
+	rows := stmt.newRows(context.Background(), aMapper)
+	rows.rowsReader = newRowsReader([]*as.Record{
+		{Bins: as.BinMap{"id": 1, "timeout": int64(time.Minute), "uuid": uuid[:], "balance": "123456789012345678901234567890", "payload": []byte(`{"a":1}`), "nickname": "bob"}},
+		{Bins: as.BinMap{"id": 2}},
+	})
+	db := sql.OpenDB(&testRowsConnector{rows: rows})
+	defer db.Close()
+	sqlRows, err := db.Query("SELECT * FROM records")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer sqlRows.Close()
+
+	var actual []string
+	for sqlRows.Next() {
+		var id int
+		var timeout time.Duration
+		var actualUUID [16]byte
+		var balance *big.Int
+		var payload json.RawMessage
+		var nickname sql.NullString
+		if !assert.Nil(t, sqlRows.Scan(&id, &timeout, &actualUUID, &balance, &payload, &nickname)) {
+			return
+		}
+		actual = append(actual, fmt.Sprintf("%v %v %x %v %q %v", id, timeout, actualUUID, balance, []byte(payload), nickname))
+	}
+	assert.Nil(t, sqlRows.Err())
+	assert.Equal(t, []string{
+		`1 1m0s 123e4567e89b12d3a456426614174000 123456789012345678901234567890 "{\"a\":1}" {bob true}`,
+		`2 0s 00000000000000000000000000000000 <nil> "" { false}`,
+	}, actual)
+}
@@ -0,0 +1,176 @@
+package aerospike
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decimal represents exact decimal value, stored in scale=N tagged bin as integer scaled by 10^N
+type Decimal interface {
+	// ScaledInt returns value multiplied by 10^scale, or error if value can not be represented exactly
+	ScaledInt(scale int) (int64, error)
+	// SetScaledInt sets value from integer multiplied by 10^scale
+	SetScaledInt(value int64, scale int) error
+}
+
+// maxDecimalScale is the largest scale with 10^scale fitting int64
+const maxDecimalScale = 18
+
+var (
+	decimalType = reflect.TypeOf((*Decimal)(nil)).Elem()
+	ratType     = reflect.TypeOf(big.Rat{})
+)
+
+// isDecimalType returns true if type can be stored in scale tagged bin
+func isDecimalType(rType reflect.Type) bool {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType == ratType || reflect.PtrTo(rType).Implements(decimalType) {
+		return true
+	}
+	return rType.Kind() == reflect.String || isNumericKind(rType.Kind())
+}
+
+func scaleFactor(scale int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+}
+
+// encodeDecimal converts decimal value (string, number, big.Rat or Decimal) to int64 scaled by 10^scale
+func encodeDecimal(value interface{}, scale int) (interface{}, error) {
+	if isNullValue(value) {
+		return nil, nil
+	}
+	if iFacePtr, ok := value.(*interface{}); ok {
+		value = *iFacePtr
+	}
+	if decimal, ok := value.(Decimal); ok {
+		return decimal.ScaledInt(scale)
+	}
+	if v := reflect.ValueOf(value); v.Kind() != reflect.Ptr && reflect.PtrTo(v.Type()).Implements(decimalType) {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr.Interface().(Decimal).ScaledInt(scale)
+	}
+	rat, err := asRat(value)
+	if err != nil {
+		return nil, err
+	}
+	scaled := new(big.Rat).Mul(rat, new(big.Rat).SetInt(scaleFactor(scale)))
+	if !scaled.IsInt() {
+		return nil, fmt.Errorf("decimal %v exceeds scale %v", rat.RatString(), scale)
+	}
+	if !scaled.Num().IsInt64() {
+		return nil, fmt.Errorf("decimal %v with scale %v overflows int64", rat.RatString(), scale)
+	}
+	return scaled.Num().Int64(), nil
+}
+
+// asRat returns exact rational number of decimal value, floats are converted with their shortest decimal representation
+func asRat(value interface{}) (*big.Rat, error) {
+	switch actual := value.(type) {
+	case *big.Rat:
+		return new(big.Rat).Set(actual), nil
+	case big.Rat:
+		return new(big.Rat).Set(&actual), nil
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("invalid decimal: %v", f)
+		}
+		bitSize := 64
+		if v.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		return parseRat(strconv.FormatFloat(f, 'f', -1, bitSize))
+	case reflect.String:
+		return parseRat(v.String())
+	}
+	// numeric kinds take precedence, so that numeric types implementing fmt.Stringer (e.g. time.Duration) are not parsed as text
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return parseRat(stringer.String())
+	}
+	return nil, fmt.Errorf("unsupported decimal type: %T", value)
+}
+
+func parseRat(text string) (*big.Rat, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(text))
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal: %q", text)
+	}
+	return rat, nil
+}
+
+// decodeDecimal converts integer scaled by 10^scale to target type value
+func decodeDecimal(value interface{}, scale int, target reflect.Type) (reflect.Value, error) {
+	if target.Kind() == reflect.Ptr {
+		elem, err := decodeDecimal(value, scale, target.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(target.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+	if v := reflect.ValueOf(value); v.IsValid() && !isNumericKind(v.Kind()) {
+		return reflect.Value{}, fmt.Errorf("expected scaled integer but had %T", value)
+	}
+	scaledValue, err := coerceNumber(value, int64Type)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	scaled := scaledValue.(int64)
+	if reflect.PtrTo(target).Implements(decimalType) {
+		ptr := reflect.New(target)
+		if err := ptr.Interface().(Decimal).SetScaledInt(scaled, scale); err != nil {
+			return reflect.Value{}, err
+		}
+		return ptr.Elem(), nil
+	}
+	rat := new(big.Rat).SetFrac(big.NewInt(scaled), scaleFactor(scale))
+	switch {
+	case target == ratType:
+		return reflect.ValueOf(rat).Elem(), nil
+	case target.Kind() == reflect.String:
+		return reflect.ValueOf(rat.FloatString(scale)).Convert(target), nil
+	case target.Kind() == reflect.Float32 || target.Kind() == reflect.Float64:
+		f, _ := rat.Float64()
+		return reflect.ValueOf(f).Convert(target), nil
+	case isNumericKind(target.Kind()):
+		if !rat.IsInt() {
+			return reflect.Value{}, fmt.Errorf("can't convert decimal %v to %v without loss", rat.FloatString(scale), target)
+		}
+		converted, err := coerceNumber(rat.Num().Int64(), target)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(converted), nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported decimal type: %v", target)
+}
+
+// decodeDecimal converts scaled integer bin value to field type value
+func (f *field) decodeDecimal(value interface{}) (interface{}, error) {
+	if value == nil {
+		return reflect.Zero(f.Type).Interface(), nil
+	}
+	result, err := decodeDecimal(value, f.tag.Scale, f.Type)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %v decimal: %w", f.Column(), err)
+	}
+	return result.Interface(), nil
+}
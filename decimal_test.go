@@ -0,0 +1,105 @@
+package aerospike
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testMoney struct {
+	Cents int64
+}
+
+func (m *testMoney) ScaledInt(scale int) (int64, error) {
+	if scale != 2 {
+		return 0, fmt.Errorf("unsupported scale: %v", scale)
+	}
+	return m.Cents, nil
+}
+
+func (m *testMoney) SetScaledInt(value int64, scale int) error {
+	if scale != 2 {
+		return fmt.Errorf("unsupported scale: %v", scale)
+	}
+	m.Cents = value
+	return nil
+}
+
+func Test_decimal(t *testing.T) {
+	type Record struct {
+		Id      int        `aerospike:"id,pk=true"`
+		Balance float64    `aerospike:"balance,scale=2"`
+		Price   string     `aerospike:"price,scale=2"`
+		Rate    *big.Rat   `aerospike:"rate,scale=4"`
+		Total   testMoney  `aerospike:"total,scale=2"`
+		Units   int        `aerospike:"units,scale=3"`
+		Limit   *float64   `aerospike:"limit,scale=2"`
+		Refund  *testMoney `aerospike:"refund,scale=2"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	limit := 99.99
+	var testCases = []struct {
+		description string
+		column      string
+		value       interface{}
+		expectBin   interface{}
+		expect      interface{}
+		expectErr   bool
+	}{
+		{description: "float", column: "balance", value: 0.1, expectBin: int64(10), expect: 0.1},
+		{description: "float from text", column: "balance", value: "12.34", expectBin: int64(1234), expect: 12.34},
+		{description: "text", column: "price", value: "19.9", expectBin: int64(1990), expect: "19.90"},
+		{description: "negative text", column: "price", value: "-0.05", expectBin: int64(-5), expect: "-0.05"},
+		{description: "big rat", column: "rate", value: big.NewRat(1, 8), expectBin: int64(1250), expect: big.NewRat(1, 8)},
+		{description: "decimal interface", column: "total", value: testMoney{Cents: 250}, expectBin: int64(250), expect: testMoney{Cents: 250}},
+		{description: "decimal interface pointer", column: "refund", value: &testMoney{Cents: 5}, expectBin: int64(5), expect: &testMoney{Cents: 5}},
+		{description: "int", column: "units", value: 7, expectBin: int64(7000), expect: 7},
+		{description: "float pointer", column: "limit", value: &limit, expectBin: int64(9999), expect: &limit},
+		{description: "nil", column: "limit", value: nil, expectBin: nil, expect: (*float64)(nil)},
+		{description: "exceeds scale", column: "balance", value: 0.125, expectErr: true},
+		{description: "invalid text", column: "price", value: "1.2.3", expectErr: true},
+		{description: "overflow", column: "units", value: int64(1) << 62, expectErr: true},
+		{description: "numeric stringer", column: "units", value: 3 * time.Nanosecond, expectBin: int64(3000), expect: 3},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		bin, err := aField.ensureValidValueType(testCase.value)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectBin, bin, testCase.description)
+		actual, err := aField.decodeValue(bin)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+
+	_, err = aMapper.getField("units").decodeValue(int64(1500))
+	assert.NotNil(t, err, "fractional decimal can not be decoded into int")
+	_, err = aMapper.getField("balance").decodeValue("12.34")
+	assert.NotNil(t, err, "non integer bin")
+}
+
+func Test_decimalTag(t *testing.T) {
+	_, err := ParseTag("amount,scale=19")
+	assert.NotNil(t, err)
+	_, err = ParseTag("amount,scale=x")
+	assert.NotNil(t, err)
+	type Record struct {
+		Id     int  `aerospike:"id,pk=true"`
+		Active bool `aerospike:"active,scale=2"`
+	}
+	_, err = newTypeBasedMapper(reflect.TypeOf(Record{}))
+	assert.NotNil(t, err)
+}
@@ -1,6 +1,7 @@
 package aerospike
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
@@ -47,7 +48,27 @@ func (d Driver) Open(dsn string) (driver.Conn, error) {
 	if ret != nil {
 		return ret, nil
 	}
+	sets := newRegistry()
+	sets.parent = globalSets
+	ret, err := openConnection(dsn, sets)
+	if err != nil {
+		return nil, err
+	}
+	if !ret.cfg.disablePool {
+		mutex.Lock()
+		connections[dsn] = ret
+		mutex.Unlock()
+	}
+	return ret, nil
+}
+
+// OpenConnector returns a connector, it implements driver.DriverContext
+func (d Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	return NewConnector(dsn), nil
+}
 
+// openConnection creates a client connection using sets registry
+func openConnection(dsn string, sets *Registry) (*connection, error) {
 	cfg, err := ParseDSN(dsn)
 	if err != nil {
 		return nil, err
@@ -76,16 +97,19 @@ func (d Driver) Open(dsn string) (driver.Conn, error) {
 	}
 
 	limiter := writeLimiter.getLimiter(dsn, cfg.maxConcurrentWrite)
-	ret, err = newConnection(cfg, client, limiter)
+	ret, err := newConnection(cfg, client, limiter, sets)
 	if err != nil {
 		return nil, err
 	}
-
-	if !cfg.disablePool {
-		mutex.Lock()
-		connections[dsn] = ret
-		mutex.Unlock()
+	if cfg.schema != "" {
+		schemaSets, err := dsnSchemas.registry(context.Background(), ret, dsn, sets.parent)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		if sets.parent != schemaSets {
+			sets.parent = schemaSets
+		}
 	}
-
 	return ret, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/viant/toolbox"
 	"log"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 )
@@ -435,6 +436,7 @@ func Test_ExecContext(t *testing.T) {
 		sql         string
 		params      []interface{}
 		expect      interface{}
+		expectErr   bool
 	}{
 		{
 			description: "register inlined set",
@@ -463,11 +465,36 @@ func Test_ExecContext(t *testing.T) {
 			dsn:         "", // dynamic
 			sql:         "REGISTER GLOBAL SET WITH TTL 100 Foo AS ?",
 			params:      []interface{}{Foo{}},
+			expectErr:   true,
 		},
 		{
 			description: "register inlined global set with ttl",
 			dsn:         "", // dynamic
 			sql:         "REGISTER GLOBAL SET WITH TTL 100 Bar AS struct{id int; name string}",
+			expectErr:   true,
+		},
+		{
+			description: "replace named global set with ttl",
+			dsn:         "", // dynamic
+			sql:         "REPLACE GLOBAL SET WITH TTL 100 Foo AS ?",
+			params:      []interface{}{Foo{}},
+		},
+		{
+			description: "replace named global set",
+			dsn:         "", // dynamic
+			sql:         "REPLACE GLOBAL SET Foo AS ?",
+			params:      []interface{}{Foo{}},
+		},
+		{
+			description: "unregister global set",
+			dsn:         "", // dynamic
+			sql:         "UNREGISTER GLOBAL SET Bar",
+		},
+		{
+			description: "unregister unknown set",
+			dsn:         "", // dynamic
+			sql:         "UNREGISTER SET Bar",
+			expectErr:   true,
 		},
 	}
 
@@ -486,6 +513,10 @@ func Test_ExecContext(t *testing.T) {
 				}
 				assert.NotNil(t, db, tc.description)
 				_, err = db.ExecContext(context.Background(), tc.sql, tc.params...)
+				if tc.expectErr {
+					assert.NotNil(t, err, tc.description)
+					return
+				}
 				assert.Nil(t, err, tc.description)
 			})
 		}
@@ -838,6 +869,98 @@ func Test_QueryContext(t *testing.T) {
 			Count int
 		}
 
+		Versioned struct {
+			Id         int    `aerospike:"id,pk=true"`
+			Name       string `aerospike:"name"`
+			Generation int    `aerospike:"gen,generation"`
+		}
+
+		Session struct {
+			Token string `aerospike:"token,pk=true"`
+			User  string `aerospike:"user"`
+			TTL   int    `aerospike:"ttl,ttl"`
+		}
+
+		Tagged struct {
+			Id   int      `aerospike:"id,pk=true"`
+			Tags []string `aerospike:"tags"`
+		}
+
+		Visits struct {
+			Id       int    `aerospike:"id,pk=true"`
+			Visitors []byte `aerospike:"visitors,hll"`
+		}
+
+		Leaderboard struct {
+			Board  string `aerospike:"board,pk=true"`
+			Player string `aerospike:"player,mapKey"`
+			Score  int    `aerospike:"score"`
+		}
+
+		Window struct {
+			Id    string `aerospike:"id,pk=true"`
+			Slot  int    `aerospike:"slot,arrayIndex,arraySize=3"`
+			Value int    `aerospike:"value"`
+		}
+
+		Document struct {
+			Id   int    `aerospike:"id,pk=true"`
+			Body string `aerospike:"body,chunked=16"`
+		}
+
+		Article struct {
+			Id   int    `aerospike:"id,pk=true"`
+			Body string `aerospike:"body,compress=gzip"`
+		}
+
+		Event struct {
+			Id int       `aerospike:"id,pk=true"`
+			At time.Time `aerospike:"at,unixms"`
+		}
+
+		Address struct {
+			City string `aerospike:"city"`
+			Zip  string `aerospike:"zip"`
+		}
+
+		Customer struct {
+			Id      int     `aerospike:"id,pk=true"`
+			Name    string  `aerospike:"name"`
+			Address Address `aerospike:"address"`
+		}
+
+		Profile struct {
+			Id     int                `aerospike:"id,pk=true"`
+			Tags   []string           `aerospike:"tags"`
+			Scores map[string]float64 `aerospike:"scores"`
+		}
+
+		PreferenceSettings struct {
+			Theme  string `json:"theme"`
+			Limits []int  `json:"limits"`
+		}
+
+		Preference struct {
+			Id       int                `aerospike:"id,pk=true"`
+			Settings PreferenceSettings `aerospike:"settings,codec=gob"`
+		}
+
+		Contact struct {
+			Id    int     `aerospike:"id,pk=true"`
+			Name  string  `aerospike:"name,omitempty"`
+			Email *string `aerospike:"email"`
+		}
+
+		Ledger struct {
+			Id      int     `aerospike:"id,pk=true"`
+			Balance float64 `aerospike:"balance,scale=2"`
+		}
+
+		Subscriber struct {
+			Id            int `aerospike:"id,pk=true"`
+			LifetimeValue int `aerospike:"lifetime_value_cents"`
+		}
+
 		CountRec struct {
 			Count int
 		}
@@ -867,8 +990,25 @@ func Test_QueryContext(t *testing.T) {
 		{SQL: "REGISTER SET barPtr AS ?", params: []interface{}{BarPtr{}}},
 		{SQL: "REGISTER SET barDoublePtr AS struct { Id int `aerospike:\"id,pk=true\"`; Seq int `aerospike:\"seq,mapKey\"`; Amount **int `aerospike:\"amount\"`; Price **float64 `aerospike:\"price\"`; Name **string `aerospike:\"name\"`; Time **time.Time `aerospike:\"time\"` }", params: []interface{}{}},
 		{SQL: "REGISTER SET authCode AS ?", params: []interface{}{AuthCode{}}},
+		{SQL: "REGISTER SET versioned AS ?", params: []interface{}{Versioned{}}},
+		{SQL: "REGISTER SET WITH TTL 60 session AS ?", params: []interface{}{Session{}}},
+		{SQL: "REGISTER SET tagged AS ?", params: []interface{}{Tagged{}}},
+		{SQL: "REGISTER SET visits AS ?", params: []interface{}{Visits{}}},
+		{SQL: "REGISTER SET WITH MAP ORDER KEY_VALUE Leaderboard/scores AS ?", params: []interface{}{Leaderboard{}}},
+		{SQL: "REGISTER SET Window/values AS ?", params: []interface{}{Window{}}},
+		{SQL: "REGISTER SET WITH MAP SHARDS 4 ShardedBoard/scores AS ?", params: []interface{}{Leaderboard{}}},
+		{SQL: "REGISTER SET documents AS ?", params: []interface{}{Document{}}},
+		{SQL: "REGISTER SET articles AS ?", params: []interface{}{Article{}}},
+		{SQL: "REGISTER SET events AS ?", params: []interface{}{Event{}}},
+		{SQL: "REGISTER SET customers AS ?", params: []interface{}{Customer{}}},
+		{SQL: "REGISTER SET profiles AS ?", params: []interface{}{Profile{}}},
+		{SQL: "REGISTER SET preferences AS ?", params: []interface{}{Preference{}}},
+		{SQL: "REGISTER SET contacts AS ?", params: []interface{}{Contact{}}},
+		{SQL: "REGISTER SET ledgers AS ?", params: []interface{}{Ledger{}}},
+		{SQL: "REGISTER SET WITH BIN ALIASING subscribers AS ?", params: []interface{}{Subscriber{}}},
 	}
 
+	contactEmail := "bob@example.com"
 	var testCases = tstCases{
 		{
 			description: "literal insert cache check",
@@ -2619,6 +2759,583 @@ func Test_QueryContext(t *testing.T) {
 				return &bar, err
 			},
 		},
+		{
+			description: "update with expected generation",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM versioned",
+				"INSERT INTO versioned(id,name) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, "v1"},
+			},
+			execSQL:     "UPDATE versioned SET name = ? WHERE pk = ? AND _generation = ?",
+			execParams:  []interface{}{"v2", 1, 1},
+			querySQL:    "SELECT id, name, _generation FROM versioned WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Versioned{Id: 1, Name: "v2", Generation: 2},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Versioned{}
+				err := r.Scan(&rec.Id, &rec.Name, &rec.Generation)
+				return &rec, err
+			},
+		},
+		{
+			description: "map insert with expected generation",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM Doc",
+				"INSERT INTO Doc/Bars(id, seq, name) VALUES(1, 100,'doc1')",
+			},
+			execSQL:     "INSERT INTO Doc/Bars(id, seq, name, _generation) VALUES(?,?,?,?)",
+			execParams:  []interface{}{1, 101, "doc2", 1},
+			querySQL:    "SELECT id, seq, name FROM Doc/Bars WHERE PK = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Doc{Id: 1, Seq: 100, Name: "doc1"},
+				&Doc{Id: 1, Seq: 101, Name: "doc2"},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				doc := Doc{}
+				err := r.Scan(&doc.Id, &doc.Seq, &doc.Name)
+				return &doc, err
+			},
+		},
+		{
+			description: "insert with never expiring per-record ttl",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM session",
+			},
+			execSQL:     "INSERT INTO session(token,user,ttl) VALUES(?,?,?)",
+			execParams:  []interface{}{"t1", "u1", -1},
+			querySQL:    "SELECT token, user, ttl FROM session WHERE pk = ?",
+			queryParams: []interface{}{"t1"},
+			expect: []interface{}{
+				&Session{Token: "t1", User: "u1", TTL: -1},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Session{}
+				err := r.Scan(&rec.Token, &rec.User, &rec.TTL)
+				return &rec, err
+			},
+		},
+		{
+			description: "touch record with _ttl update",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM session",
+				"INSERT INTO session(token,user) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{"t2", "u2"},
+			},
+			execSQL:     "UPDATE session SET _ttl = ? WHERE pk = ?",
+			execParams:  []interface{}{-1, "t2"},
+			querySQL:    "SELECT token, user, _ttl FROM session WHERE pk = ?",
+			queryParams: []interface{}{"t2"},
+			expect: []interface{}{
+				&Session{Token: "t2", User: "u2", TTL: -1},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Session{}
+				err := r.Scan(&rec.Token, &rec.User, &rec.TTL)
+				return &rec, err
+			},
+		},
+		{
+			description: "update with returning",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM versioned",
+				"INSERT INTO versioned(id,name) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{3, "v1"},
+			},
+			querySQL:    "UPDATE versioned SET name = ? WHERE pk = ? RETURNING name, _generation",
+			queryParams: []interface{}{"v2", 3},
+			expect: []interface{}{
+				&Versioned{Name: "v2", Generation: 2},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Versioned{}
+				err := r.Scan(&rec.Name, &rec.Generation)
+				return &rec, err
+			},
+		},
+		{
+			description: "update list bin with list_append function",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM tagged",
+				"INSERT INTO tagged(id,tags) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, []string{"a", "b"}},
+			},
+			execSQL:     "UPDATE tagged SET tags = LIST_APPEND(tags, ?) WHERE pk = ?",
+			execParams:  []interface{}{[]string{"c", "d"}, 1},
+			querySQL:    "SELECT id, tags FROM tagged WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Tagged{Id: 1, Tags: []string{"a", "b", "c", "d"}},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Tagged{}
+				err := r.Scan(&rec.Id, &rec.Tags)
+				return &rec, err
+			},
+		},
+		{
+			description: "hll add and count unique visitors",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM visits",
+			},
+			initParams: [][]interface{}{
+				{},
+			},
+			execSQL:     "UPDATE visits SET visitors = HLL_ADD(visitors, ?) WHERE pk = ?",
+			execParams:  []interface{}{[]string{"u1", "u2", "u1"}, 1},
+			querySQL:    "SELECT id, HLL_COUNT(visitors) AS cnt FROM visits WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&CountRecGroup{ID: 1, Count: 2},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := CountRecGroup{}
+				err := r.Scan(&rec.ID, &rec.Count)
+				return &rec, err
+			},
+		},
+		{
+			description: "map bin top n by value rank",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM Leaderboard/scores",
+				"INSERT INTO Leaderboard/scores(board,player,score) VALUES(?,?,?),(?,?,?),(?,?,?),(?,?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{"b1", "p1", 10, "b1", "p2", 40, "b1", "p3", 30, "b1", "p4", 20},
+			},
+			querySQL:    "SELECT board, player, score FROM Leaderboard/scores WHERE pk = ? ORDER BY value DESC LIMIT 2",
+			queryParams: []interface{}{"b1"},
+			expect: []interface{}{
+				&Leaderboard{Board: "b1", Player: "p2", Score: 40},
+				&Leaderboard{Board: "b1", Player: "p3", Score: 30},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Leaderboard{}
+				err := r.Scan(&rec.Board, &rec.Player, &rec.Score)
+				return &rec, err
+			},
+		},
+		{
+			description: "map bin entries by value range",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM Leaderboard/scores",
+				"INSERT INTO Leaderboard/scores(board,player,score) VALUES(?,?,?),(?,?,?),(?,?,?),(?,?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{"b1", "p1", 10, "b1", "p2", 40, "b1", "p3", 30, "b1", "p4", 20},
+			},
+			querySQL:    "SELECT board, player, score FROM Leaderboard/scores WHERE pk = ? AND value BETWEEN ? AND ? ORDER BY value",
+			queryParams: []interface{}{"b1", 20, 30},
+			expect: []interface{}{
+				&Leaderboard{Board: "b1", Player: "p4", Score: 20},
+				&Leaderboard{Board: "b1", Player: "p3", Score: 30},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Leaderboard{}
+				err := r.Scan(&rec.Board, &rec.Player, &rec.Score)
+				return &rec, err
+			},
+		},
+		{
+			description: "bounded list keeps last arraySize items",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM Window/values",
+				"INSERT INTO Window/values(id,value) VALUES(?,?),(?,?)",
+				"INSERT INTO Window/values(id,value) VALUES(?,?),(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{"w1", 1, "w1", 2},
+				{"w1", 3, "w1", 4},
+			},
+			querySQL:    "SELECT id, slot, value FROM Window/values WHERE pk = ?",
+			queryParams: []interface{}{"w1"},
+			expect: []interface{}{
+				&Window{Id: "w1", Slot: 0, Value: 2},
+				&Window{Id: "w1", Slot: 1, Value: 3},
+				&Window{Id: "w1", Slot: 2, Value: 4},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Window{}
+				err := r.Scan(&rec.Id, &rec.Slot, &rec.Value)
+				return &rec, err
+			},
+		},
+		{
+			description: "sharded map bin top n by value rank",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM ShardedBoard/scores",
+				"INSERT INTO ShardedBoard/scores(board,player,score) VALUES(?,?,?),(?,?,?),(?,?,?),(?,?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{"b1", "p1", 10, "b1", "p2", 40, "b1", "p3", 30, "b1", "p4", 20},
+			},
+			querySQL:    "SELECT board, player, score FROM ShardedBoard/scores WHERE pk = ? ORDER BY value DESC LIMIT 3",
+			queryParams: []interface{}{"b1"},
+			expect: []interface{}{
+				&Leaderboard{Board: "b1", Player: "p2", Score: 40},
+				&Leaderboard{Board: "b1", Player: "p3", Score: 30},
+				&Leaderboard{Board: "b1", Player: "p4", Score: 20},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Leaderboard{}
+				err := r.Scan(&rec.Board, &rec.Player, &rec.Score)
+				return &rec, err
+			},
+		},
+		{
+			description: "sharded map bin entry update",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM ShardedBoard/scores",
+				"INSERT INTO ShardedBoard/scores(board,player,score) VALUES(?,?,?),(?,?,?),(?,?,?)",
+				"UPDATE ShardedBoard/scores SET score = ? WHERE pk = ? AND player = ?",
+			},
+			initParams: [][]interface{}{
+				{},
+				{"b1", "p1", 10, "b1", "p2", 40, "b1", "p3", 30},
+				{35, "b1", "p3"},
+			},
+			querySQL:    "SELECT board, player, score FROM ShardedBoard/scores WHERE pk = ? AND player = ?",
+			queryParams: []interface{}{"b1", "p3"},
+			expect: []interface{}{
+				&Leaderboard{Board: "b1", Player: "p3", Score: 35},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Leaderboard{}
+				err := r.Scan(&rec.Board, &rec.Player, &rec.Score)
+				return &rec, err
+			},
+		},
+		{
+			description: "null map bin payload update removes entry",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM Leaderboard/scores",
+				"INSERT INTO Leaderboard/scores(board,player,score) VALUES(?,?,?),(?,?,?)",
+				"UPDATE Leaderboard/scores SET score = NULL WHERE pk = ? AND player = ?",
+			},
+			initParams: [][]interface{}{
+				{},
+				{"b1", "p1", 10, "b1", "p2", 40},
+				{"b1", "p2"},
+			},
+			querySQL:    "SELECT board, player, score FROM Leaderboard/scores WHERE pk = ?",
+			queryParams: []interface{}{"b1"},
+			expect: []interface{}{
+				&Leaderboard{Board: "b1", Player: "p1", Score: 10},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Leaderboard{}
+				err := r.Scan(&rec.Board, &rec.Player, &rec.Score)
+				return &rec, err
+			},
+		},
+		{
+			description: "chunked bin reassembled after update",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM documents",
+				"INSERT INTO documents(id,body) VALUES(?,?)",
+				"UPDATE documents SET body = ? WHERE pk = ?",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, strings.Repeat("rendered document ", 10)},
+				{strings.Repeat("updated ", 5), 1},
+			},
+			querySQL:    "SELECT id, body FROM documents WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Document{Id: 1, Body: strings.Repeat("updated ", 5)},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Document{}
+				err := r.Scan(&rec.Id, &rec.Body)
+				return &rec, err
+			},
+		},
+		{
+			description: "chunked bin reassembled after ttl update",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM documents",
+				"INSERT INTO documents(id,body) VALUES(?,?)",
+				"UPDATE documents SET _ttl = ? WHERE pk = ?",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, strings.Repeat("rendered document ", 10)},
+				{-1, 1},
+			},
+			querySQL:    "SELECT id, body FROM documents WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Document{Id: 1, Body: strings.Repeat("rendered document ", 10)},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Document{}
+				err := r.Scan(&rec.Id, &rec.Body)
+				return &rec, err
+			},
+		},
+		{
+			description: "chunked record deleted by pk",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM documents",
+				"INSERT INTO documents(id,body) VALUES(?,?),(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, strings.Repeat("first document ", 10), 2, strings.Repeat("second document ", 10)},
+			},
+			execSQL:     "DELETE FROM documents WHERE pk = ?",
+			execParams:  []interface{}{1},
+			querySQL:    "SELECT id, body FROM documents",
+			queryParams: []interface{}{},
+			expect: []interface{}{
+				&Document{Id: 2, Body: strings.Repeat("second document ", 10)},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Document{}
+				err := r.Scan(&rec.Id, &rec.Body)
+				return &rec, err
+			},
+		},
+		{
+			description: "compressed bin decompressed on read",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM articles",
+				"INSERT INTO articles(id,body) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, strings.Repeat("compressible body ", 20)},
+			},
+			querySQL:    "SELECT id, body FROM articles WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Article{Id: 1, Body: strings.Repeat("compressible body ", 20)},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Article{}
+				err := r.Scan(&rec.Id, &rec.Body)
+				return &rec, err
+			},
+		},
+		{
+			description: "time stored as epoch milliseconds",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM events",
+				"INSERT INTO events(id,at) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, time.Date(2024, 3, 10, 12, 30, 15, 500000000, time.UTC)},
+			},
+			querySQL:    "SELECT id, at FROM events WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Event{Id: 1, At: time.Date(2024, 3, 10, 12, 30, 15, 500000000, time.UTC)},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Event{}
+				err := r.Scan(&rec.Id, &rec.At)
+				return &rec, err
+			},
+		},
+		{
+			description: "nested struct column update and select",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM customers",
+				"INSERT INTO customers(id,name,address) VALUES(?,?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, "Bob", Address{City: "Austin", Zip: "73301"}},
+			},
+			execSQL:     "UPDATE customers SET address.city = ? WHERE pk = ?",
+			execParams:  []interface{}{"Dallas", 1},
+			querySQL:    "SELECT id, address.city FROM customers WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Customer{Id: 1, Address: Address{City: "Dallas"}},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Customer{}
+				err := r.Scan(&rec.Id, &rec.Address.City)
+				return &rec, err
+			},
+		},
+		{
+			description: "nested struct column criteria",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM customers",
+				"INSERT INTO customers(id,name,address) VALUES(?,?,?)",
+				"INSERT INTO customers(id,name,address) VALUES(?,?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, "Bob", Address{City: "Austin", Zip: "73301"}},
+				{2, "Ann", Address{City: "Dallas", Zip: "75201"}},
+			},
+			querySQL:    "SELECT id, name, address FROM customers WHERE address.zip = ?",
+			queryParams: []interface{}{"75201"},
+			expect: []interface{}{
+				&Customer{Id: 2, Name: "Ann", Address: Address{City: "Dallas", Zip: "75201"}},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Customer{}
+				err := r.Scan(&rec.Id, &rec.Name, &rec.Address)
+				return &rec, err
+			},
+		},
+		{
+			description: "typed slice and map bins",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM profiles",
+				"INSERT INTO profiles(id,tags,scores) VALUES(?,?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, []string{"go", "sql"}, map[string]float64{"go": 9.5}},
+			},
+			querySQL:    "SELECT id, tags, scores FROM profiles WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Profile{Id: 1, Tags: []string{"go", "sql"}, Scores: map[string]float64{"go": 9.5}},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Profile{}
+				err := r.Scan(&rec.Id, &rec.Tags, &rec.Scores)
+				return &rec, err
+			},
+		},
+		{
+			description: "codec blob bin",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM preferences",
+				"INSERT INTO preferences(id,settings) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, PreferenceSettings{Theme: "dark", Limits: []int{1, 2}}},
+			},
+			querySQL:    "SELECT id, settings FROM preferences WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Preference{Id: 1, Settings: PreferenceSettings{Theme: "dark", Limits: []int{1, 2}}},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Preference{}
+				err := r.Scan(&rec.Id, &rec.Settings)
+				return &rec, err
+			},
+		},
+		{
+			description: "null criteria and omitempty",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM contacts",
+				"INSERT INTO contacts(id,name,email) VALUES(?,?,?)",
+				"INSERT INTO contacts(id,name,email) VALUES(?,?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, "Bob", &contactEmail},
+				{2, "", nil},
+			},
+			querySQL: "SELECT id, name, email FROM contacts WHERE email IS NULL",
+			expect: []interface{}{
+				&Contact{Id: 2},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Contact{}
+				err := r.Scan(&rec.Id, &rec.Name, &rec.Email)
+				return &rec, err
+			},
+		},
+		{
+			description: "scaled decimal arithmetic",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM ledgers",
+				"INSERT INTO ledgers(id,balance) VALUES(?,?)",
+				"UPDATE ledgers SET balance = balance + 0.20 WHERE pk = ?",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, 0.1},
+				{1},
+			},
+			querySQL:    "SELECT id, balance FROM ledgers WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Ledger{Id: 1, Balance: 0.3},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Ledger{}
+				err := r.Scan(&rec.Id, &rec.Balance)
+				return &rec, err
+			},
+		},
+		{
+			description: "aliased long bin name",
+			dsn:         "", // dynamic
+			init: []string{
+				"DELETE FROM subscribers",
+				"INSERT INTO subscribers(id,lifetime_value_cents) VALUES(?,?)",
+			},
+			initParams: [][]interface{}{
+				{},
+				{1, 1250},
+			},
+			querySQL:    "SELECT id, lifetime_value_cents FROM subscribers WHERE pk = ?",
+			queryParams: []interface{}{1},
+			expect: []interface{}{
+				&Subscriber{Id: 1, LifetimeValue: 1250},
+			},
+			scanner: func(r *sql.Rows) (interface{}, error) {
+				rec := Subscriber{}
+				err := r.Scan(&rec.Id, &rec.LifetimeValue)
+				return &rec, err
+			},
+		},
 	}
 
 	//testCases = testCases[0:1]
@@ -50,6 +50,7 @@ func ParseDSN(dsn string) (*Config, error) {
 		concurrency:           defaultConcurrency,
 		Values:                URL.Query(),
 		insertCacheMaxEntries: defaultInsertCacheMaxEntries,
+		inferSampleSize:       defaultInferSampleSize,
 	}
 
 	if len(cfg.Values) > 0 {
@@ -80,6 +81,21 @@ func ParseDSN(dsn string) (*Config, error) {
 				return nil, fmt.Errorf("invalid dsn insertCacheMaxEntries: %v", err)
 			}
 		}
+		if v, ok := cfg.Values["schema"]; ok {
+			cfg.schema = v[0]
+		}
+		if v, ok := cfg.Values["inferSchema"]; ok {
+			if len(v) > 0 {
+				cfg.inferSchema = v[0] == "true"
+			} else {
+				cfg.inferSchema = true
+			}
+		}
+		if v, ok := cfg.Values["inferSampleSize"]; ok {
+			if cfg.inferSampleSize, err = strconv.Atoi(v[0]); err != nil || cfg.inferSampleSize <= 0 {
+				return nil, fmt.Errorf("invalid dsn inferSampleSize: %v", v[0])
+			}
+		}
 		if v, ok := cfg.Values["disableCache"]; ok {
 			if len(v) > 0 {
 				cfg.disableCache = v[0] == "true"
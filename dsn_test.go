@@ -29,6 +29,7 @@ func Test_ParseDSN(t *testing.T) {
 				maxConcurrentWrite:    0,
 				Values:                url.Values{},
 				insertCacheMaxEntries: defaultInsertCacheMaxEntries,
+				inferSampleSize:       defaultInferSampleSize,
 				disablePool:           false,
 				disableCache:          false,
 			},
@@ -66,6 +67,7 @@ func Test_ParseDSN(t *testing.T) {
 				concurrency:           10,
 				maxConcurrentWrite:    5,
 				insertCacheMaxEntries: 17,
+				inferSampleSize:       defaultInferSampleSize,
 				disablePool:           true,
 				disableCache:          true,
 				Values: url.Values{
@@ -94,6 +96,26 @@ func Test_ParseDSN(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "dsn with schema",
+			dsn:         "aerospike://127.0.0.1:3000/namespace_abc?schema=/etc/aerospike/sets.yaml&inferSchema=true&inferSampleSize=20",
+			expect: &Config{
+				host:        "127.0.0.1",
+				port:        3000,
+				namespace:   "namespace_abc",
+				batchSize:   defaultBatchSize,
+				concurrency: defaultConcurrency,
+				Values: url.Values{
+					"schema":          []string{"/etc/aerospike/sets.yaml"},
+					"inferSchema":     []string{"true"},
+					"inferSampleSize": []string{"20"},
+				},
+				insertCacheMaxEntries: defaultInsertCacheMaxEntries,
+				schema:                "/etc/aerospike/sets.yaml",
+				inferSchema:           true,
+				inferSampleSize:       20,
+			},
+		},
 	}
 
 	for _, tc := range testCase {
@@ -0,0 +1,149 @@
+package aerospike
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// encryptionFormat represents ciphertext header format version
+const encryptionFormat byte = 1
+
+// KeyProvider provides AES-128, AES-192 or AES-256 keys of encrypted bins, keys are identified by id stored
+// in ciphertext header, so that values encrypted with rotated keys can still be decrypted
+type KeyProvider interface {
+	// CurrentKey returns id and key used to encrypt new values
+	CurrentKey() (string, []byte, error)
+	// Key returns key with supplied id
+	Key(id string) ([]byte, error)
+}
+
+var globalKeyProvider = struct {
+	mux      sync.RWMutex
+	provider KeyProvider
+}{}
+
+// RegisterKeyProvider registers driver key provider used by sets without own key provider
+func RegisterKeyProvider(provider KeyProvider) {
+	globalKeyProvider.mux.Lock()
+	globalKeyProvider.provider = provider
+	globalKeyProvider.mux.Unlock()
+}
+
+// WithKeyProvider sets key provider of set encrypted bins
+func WithKeyProvider(provider KeyProvider) Option {
+	return func(s *set) {
+		s.keyProvider = provider
+	}
+}
+
+// isStringType returns true if type is string or string pointer
+func isStringType(rType reflect.Type) bool {
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType.Kind() == reflect.String
+}
+
+// encryptionKeyProvider returns set key provider or driver key provider
+func (s *set) encryptionKeyProvider() KeyProvider {
+	if s != nil && s.keyProvider != nil {
+		return s.keyProvider
+	}
+	globalKeyProvider.mux.RLock()
+	defer globalKeyProvider.mux.RUnlock()
+	return globalKeyProvider.provider
+}
+
+// encrypt encrypts string or []byte value with AES-GCM, ciphertext is prefixed with format byte, key id length, key id and nonce,
+// column name is authenticated to prevent moving values across bins
+func (f *field) encrypt(provider KeyProvider, value interface{}) (interface{}, error) {
+	data, err := chunkData(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt %v: %w", f.Column(), err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("unable to encrypt %v: key provider was not registered", f.Column())
+	}
+	id, key, err := provider.CurrentKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt %v: %w", f.Column(), err)
+	}
+	if len(id) > 255 {
+		return nil, fmt.Errorf("unable to encrypt %v: key id too long: %v", f.Column(), len(id))
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt %v: %w", f.Column(), err)
+	}
+	header := make([]byte, 0, 2+len(id)+aead.NonceSize())
+	header = append(header, encryptionFormat, byte(len(id)))
+	header = append(header, id...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("unable to encrypt %v: %w", f.Column(), err)
+	}
+	header = append(header, nonce...)
+	return aead.Seal(header, nonce, data, []byte(f.Column())), nil
+}
+
+// decrypt decrypts value encrypted with encrypt
+func (f *field) decrypt(provider KeyProvider, value interface{}) (interface{}, error) {
+	data, ok := value.([]byte)
+	if !ok {
+		if value == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to decrypt %v: expected []byte but had %T", f.Column(), value)
+	}
+	if len(data) < 2 || data[0] != encryptionFormat || len(data) < 2+int(data[1]) {
+		return nil, fmt.Errorf("unable to decrypt %v: invalid ciphertext header", f.Column())
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("unable to decrypt %v: key provider was not registered", f.Column())
+	}
+	id := string(data[2 : 2+int(data[1])])
+	key, err := provider.Key(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt %v with key %v: %w", f.Column(), id, err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt %v: %w", f.Column(), err)
+	}
+	data = data[2+len(id):]
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("unable to decrypt %v: invalid ciphertext", f.Column())
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(f.Column()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt %v with key %v: %w", f.Column(), id, err)
+	}
+	if f.tag.Compress != "" {
+		return plain, nil
+	}
+	return f.decompressed(plain), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt encrypts field value with statement set key provider
+func (s *Statement) encrypt(aField *field, value interface{}) (interface{}, error) {
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return nil, err
+	}
+	return aField.encrypt(aSet.encryptionKeyProvider(), value)
+}
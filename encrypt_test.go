@@ -0,0 +1,123 @@
+package aerospike
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testKeyProvider struct {
+	current string
+	keys    map[string][]byte
+}
+
+func (p *testKeyProvider) CurrentKey() (string, []byte, error) {
+	key, err := p.Key(p.current)
+	return p.current, key, err
+}
+
+func (p *testKeyProvider) Key(id string) ([]byte, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key: %v", id)
+	}
+	return key, nil
+}
+
+func Test_fieldEncrypt(t *testing.T) {
+	type Record struct {
+		Id     int    `aerospike:"id,pk=true"`
+		Secret string `aerospike:"secret,encrypt"`
+		Token  []byte `aerospike:"token,encrypt"`
+		Note   string `aerospike:"note,compress=gzip,encrypt"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	provider := &testKeyProvider{current: "k1", keys: map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 16),
+	}}
+	note := strings.Repeat("compressible note ", 20)
+	var testCases = []struct {
+		description string
+		column      string
+		value       interface{}
+		expect      interface{}
+	}{
+		{description: "string", column: "secret", value: "top secret", expect: "top secret"},
+		{description: "bytes", column: "token", value: []byte{1, 2, 3}, expect: []byte{1, 2, 3}},
+		{description: "compressed string", column: "note", value: note, expect: note},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		value, err := aField.ensureValidValueType(testCase.value)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		encrypted, err := aField.encrypt(provider, value)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.NotContains(t, string(encrypted.([]byte)), fmt.Sprintf("%s", testCase.value), testCase.description)
+		decrypted, err := aField.decrypt(provider, encrypted)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if aField.tag.Compress != "" {
+			decrypted, err = aField.decompress(decrypted)
+			assert.Nil(t, err, testCase.description)
+		}
+		assert.EqualValues(t, testCase.expect, decrypted, testCase.description)
+	}
+
+	secret := aMapper.getField("secret")
+	encrypted, err := secret.encrypt(provider, "rotated")
+	assert.Nil(t, err)
+	provider.current = "k2"
+	decrypted, err := secret.decrypt(provider, encrypted)
+	assert.Nil(t, err, "decrypt with rotated key")
+	assert.EqualValues(t, "rotated", decrypted)
+
+	_, err = aMapper.getField("token").decrypt(provider, encrypted)
+	assert.NotNil(t, err, "ciphertext bound to column")
+
+	tampered := append([]byte{}, encrypted.([]byte)...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = secret.decrypt(provider, tampered)
+	assert.NotNil(t, err, "tampered ciphertext")
+
+	_, err = secret.decrypt(&testKeyProvider{keys: map[string][]byte{}}, encrypted)
+	assert.NotNil(t, err, "unknown key id")
+
+	_, err = secret.encrypt(nil, "value")
+	assert.NotNil(t, err, "missing key provider")
+}
+
+func Test_encryptTagValidation(t *testing.T) {
+	type Record struct {
+		Id string `aerospike:"id,pk=true,encrypt"`
+	}
+	_, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	assert.NotNil(t, err)
+
+	type Numeric struct {
+		Id     string  `aerospike:"id,pk=true"`
+		Amount float64 `aerospike:"amount,encrypt"`
+	}
+	_, err = newTypeBasedMapper(reflect.TypeOf(Numeric{}))
+	assert.NotNil(t, err, "non string or []byte field")
+
+	type Supported struct {
+		Id      string   `aerospike:"id,pk=true"`
+		Secret  *string  `aerospike:"secret,encrypt"`
+		Token   []byte   `aerospike:"token,encrypt"`
+		Profile struct{} `aerospike:"profile,codec=json,encrypt"`
+	}
+	_, err = newTypeBasedMapper(reflect.TypeOf(Supported{}))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,166 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/viant/sqlparser"
+	"github.com/viant/sqlparser/expr"
+)
+
+// binFunction represents select list bin function i.e. HLL_COUNT(visitors)
+type binFunction struct {
+	alias string
+	name  string
+	bin   string
+	args  []interface{}
+}
+
+// isBinFunction returns true if supplied lower case function name operates on a bin
+func isBinFunction(name string) bool {
+	switch name {
+	case hllCountFunction, hllUnionCountFunction, hllIntersectCountFunction, bitGetFunction, bitCountFunction:
+		return true
+	}
+	return false
+}
+
+// binFunctionType returns function result type
+func binFunctionType(name string) reflect.Type {
+	if name == bitGetFunction {
+		return reflect.TypeOf([]byte{})
+	}
+	return reflect.TypeOf(0)
+}
+
+// newBinFunction creates a bin function, only literal arguments are supported
+func newBinFunction(alias string, call *expr.Call, typeMapper *mapper) (*binFunction, error) {
+	ret := &binFunction{alias: alias, name: strings.ToLower(sqlparser.Stringify(call.X))}
+	if len(call.Args) == 0 {
+		return nil, fmt.Errorf("invalid %v call: missing column argument", ret.name)
+	}
+	column := strings.Trim(sqlparser.Stringify(call.Args[0]), "`")
+	aField := typeMapper.getField(column)
+	if aField == nil {
+		return nil, fmt.Errorf("invalid %v call: unable to find column %v", ret.name, column)
+	}
+	ret.bin = aField.Column()
+	switch ret.name {
+	case hllCountFunction, hllUnionCountFunction, hllIntersectCountFunction:
+		if !aField.tag.IsHLL {
+			return nil, fmt.Errorf("invalid %v call: column %v is not tagged with hll", ret.name, column)
+		}
+	}
+	for _, arg := range call.Args[1:] {
+		values, err := expr.NewValues(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v call: %w", ret.name, err)
+		}
+		if len(values.X) != 1 || values.X[0].Placeholder {
+			return nil, fmt.Errorf("invalid %v call: unsupported argument: %v, only literals are supported", ret.name, sqlparser.Stringify(arg))
+		}
+		ret.args = append(ret.args, values.X[0].Value)
+	}
+	return ret, nil
+}
+
+// operation returns read operation computing function value
+func (f *binFunction) operation() (*as.Operation, error) {
+	switch f.name {
+	case hllCountFunction:
+		return as.HLLGetCountOp(f.bin), nil
+	case bitGetFunction, bitCountFunction:
+		return bitReadOperation(f)
+	}
+	return nil, fmt.Errorf("unsupported %v function", f.name)
+}
+
+// handleBinFunctions computes select list bin functions with a single operate call per record
+func (s *Statement) handleBinFunctions(ctx context.Context, keys []*as.Key, rows *Rows) (driver.Rows, error) {
+	aMapper := rows.mapper
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("unsupported bin function query without pk criteria")
+	}
+	if s.collectionBin != "" {
+		return nil, fmt.Errorf("unsupported bin function query with %v collection bin", s.collectionBin)
+	}
+	var functions []*binFunction
+	for _, aField := range aMapper.fields {
+		if function, ok := aMapper.binFunctions[aField.Column()]; ok {
+			functions = append(functions, function)
+		}
+	}
+	if isHLLSetFunction(functions[0].name) {
+		if len(functions) != len(aMapper.fields) {
+			return nil, fmt.Errorf("unsupported %v function with other columns", functions[0].name)
+		}
+		return s.handleHLLSetCount(ctx, keys, rows, functions)
+	}
+
+	type binResult struct {
+		bin   string
+		index int
+	}
+	var ops []*as.Operation
+	var results = make(map[string]binResult)
+	var opCount = make(map[string]int)
+	for _, aField := range aMapper.fields {
+		if aField.isPseudo || aField.isMeta || aField.tag.IsGeneration || aField.tag.IsTTL {
+			continue
+		}
+		column := aField.Column()
+		bin := column
+		op := as.GetBinOp(column)
+		if function, ok := aMapper.binFunctions[column]; ok {
+			var err error
+			if op, err = function.operation(); err != nil {
+				return nil, err
+			}
+			bin = function.bin
+		} else if aField.isFunc {
+			return nil, fmt.Errorf("unsupported function column %v with bin functions", column)
+		}
+		results[column] = binResult{bin: bin, index: opCount[bin]}
+		opCount[bin]++
+		ops = append(ops, op)
+	}
+
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return nil, err
+	}
+	var records []*as.Record
+	for _, key := range keys {
+		writePolicy := s.writePolicy(aSet, false)
+		writePolicy.RespondPerEachOp = true
+		record, err := s.operateWithCtx(ctx, writePolicy, key, ops)
+		if err != nil {
+			if IsKeyNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		bins := as.BinMap{}
+		for column, result := range results {
+			value := record.Bins[result.bin]
+			if opCount[result.bin] > 1 {
+				if values, ok := value.([]interface{}); ok && result.index < len(values) {
+					value = values[result.index]
+				}
+			}
+			bins[column] = value
+		}
+		if len(aMapper.pk) > 0 && key.Value() != nil {
+			if _, ok := results[aMapper.pk[0].Column()]; ok && bins[aMapper.pk[0].Column()] == nil {
+				bins[aMapper.pk[0].Column()] = key.Value().GetObject()
+			}
+		}
+		records = append(records, &as.Record{Key: key, Bins: bins, Generation: record.Generation, Expiration: record.Expiration})
+	}
+	rows.rowsReader = newRowsReader(records)
+	return rows, nil
+}
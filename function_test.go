@@ -0,0 +1,93 @@
+package aerospike
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlparser"
+	"reflect"
+	"testing"
+)
+
+func Test_newQueryMapper_binFunctions(t *testing.T) {
+	type Record struct {
+		Id       int    `aerospike:"id,pk=true"`
+		Visitors []byte `aerospike:"visitors,hll"`
+		Flags    []byte `aerospike:"flags"`
+	}
+	typeMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      map[string]*binFunction
+		expectType  map[string]reflect.Type
+		expectErr   bool
+	}{
+		{
+			description: "hll count",
+			SQL:         "SELECT id, HLL_COUNT(visitors) AS cnt FROM x WHERE pk = 1",
+			expect:      map[string]*binFunction{"cnt": {alias: "cnt", name: hllCountFunction, bin: "visitors"}},
+			expectType:  map[string]reflect.Type{"cnt": reflect.TypeOf(0)},
+		},
+		{
+			description: "bit get and count",
+			SQL:         "SELECT BIT_GET(flags, 0, 8) AS b, BIT_COUNT(flags, 0, 16) AS c FROM x WHERE pk = 1",
+			expect: map[string]*binFunction{
+				"b": {alias: "b", name: bitGetFunction, bin: "flags", args: []interface{}{float64(0), 8}},
+				"c": {alias: "c", name: bitCountFunction, bin: "flags", args: []interface{}{float64(0), 16}},
+			},
+			expectType: map[string]reflect.Type{"b": reflect.TypeOf([]byte{}), "c": reflect.TypeOf(0)},
+		},
+		{
+			description: "hll count on non hll column",
+			SQL:         "SELECT HLL_COUNT(flags) AS cnt FROM x WHERE pk = 1",
+			expectErr:   true,
+		},
+		{
+			description: "placeholder argument",
+			SQL:         "SELECT BIT_COUNT(flags, ?, 8) AS c FROM x WHERE pk = 1",
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		aQuery, err := sqlparser.ParseQuery(testCase.SQL)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		aMapper, err := newQueryMapper(reflect.TypeOf(Record{}), aQuery, typeMapper)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, aMapper.binFunctions, testCase.description)
+		for alias, expectType := range testCase.expectType {
+			assert.Equal(t, expectType, aMapper.getField(alias).Type, testCase.description)
+		}
+	}
+}
+
+func Test_bitSetOperations(t *testing.T) {
+	type Record struct {
+		Id    int    `aerospike:"id,pk=true"`
+		Flags []byte `aerospike:"flags"`
+		Name  string `aerospike:"name"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	ops, err := bitSetOperations(aMapper.getField("flags"), []interface{}{3, true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ops))
+	ops, err = bitSetOperations(aMapper.getField("flags"), []interface{}{0, 16, []byte{0xff, 0x01}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ops))
+	_, err = bitSetOperations(aMapper.getField("flags"), []interface{}{0, 2})
+	assert.NotNil(t, err)
+	_, err = bitSetOperations(aMapper.getField("name"), []interface{}{0, 1})
+	assert.NotNil(t, err)
+}
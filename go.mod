@@ -16,6 +16,7 @@ require (
 	github.com/viant/x v0.3.0
 	github.com/viant/xreflect v0.6.2
 	github.com/viant/xunsafe v0.9.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -33,5 +34,4 @@ require (
 	google.golang.org/grpc v1.63.2 // indirect
 	google.golang.org/protobuf v1.33.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
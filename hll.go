@@ -0,0 +1,105 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+const (
+	hllAddFunction            = "hll_add"
+	hllCountFunction          = "hll_count"
+	hllUnionCountFunction     = "hll_union_count"
+	hllIntersectCountFunction = "hll_intersect_count"
+
+	// defaultHLLIndexBits represents default HyperLogLog index bit count
+	defaultHLLIndexBits = 14
+)
+
+// hllIndexBits returns HyperLogLog index bit count of the field
+func (f *field) hllIndexBits() int {
+	if f.tag.HLLIndexBits > 0 {
+		return f.tag.HLLIndexBits
+	}
+	return defaultHLLIndexBits
+}
+
+// hllAddOperation returns HyperLogLog add operation for supplied values
+func hllAddOperation(aField *field, values []interface{}) (*as.Operation, error) {
+	if !aField.tag.IsHLL {
+		return nil, fmt.Errorf("invalid %v call: column %v is not tagged with hll", hllAddFunction, aField.Column())
+	}
+	items := aField.listItems(values)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("invalid %v call: expected at least one value", hllAddFunction)
+	}
+	var list = make([]as.Value, 0, len(items))
+	for _, item := range items {
+		list = append(list, as.NewValue(item))
+	}
+	return as.HLLAddOp(as.DefaultHLLPolicy(), aField.Column(), list, aField.hllIndexBits(), -1), nil
+}
+
+// isHLLSetFunction returns true if function counts HyperLogLog union or intersection across records
+func isHLLSetFunction(name string) bool {
+	return name == hllUnionCountFunction || name == hllIntersectCountFunction
+}
+
+// handleHLLSetCount computes HyperLogLog union or intersection counts across all matched records as a single row
+func (s *Statement) handleHLLSetCount(ctx context.Context, keys []*as.Key, rows *Rows, functions []*binFunction) (driver.Rows, error) {
+	var bins []string
+	for _, function := range functions {
+		if !isHLLSetFunction(function.name) {
+			return nil, fmt.Errorf("unsupported %v function with %v", function.name, functions[0].name)
+		}
+		bins = append(bins, function.bin)
+	}
+	records, err := s.batchGetWithCtx(ctx, nil, keys, bins)
+	if err != nil && !IsKeyNotFound(err) {
+		return nil, err
+	}
+	aSet, lErr := s.lookupSet()
+	if lErr != nil {
+		return nil, lErr
+	}
+	result := as.BinMap{}
+	for _, function := range functions {
+		var key *as.Key
+		var others []as.HLLValue
+		for _, record := range records {
+			if record == nil {
+				continue
+			}
+			value, ok := record.Bins[function.bin].(as.HLLValue)
+			if !ok {
+				continue
+			}
+			if key == nil {
+				key = record.Key
+				continue
+			}
+			others = append(others, value)
+		}
+		if key == nil {
+			result[function.alias] = 0
+			continue
+		}
+		op := as.HLLGetCountOp(function.bin)
+		if len(others) > 0 {
+			if function.name == hllUnionCountFunction {
+				op = as.HLLGetUnionCountOp(function.bin, others)
+			} else {
+				op = as.HLLGetIntersectCountOp(function.bin, others)
+			}
+		}
+		record, err := s.operateWithCtx(ctx, s.writePolicy(aSet, false), key, []*as.Operation{op})
+		if err != nil {
+			return nil, err
+		}
+		result[function.alias] = record.Bins[function.bin]
+	}
+	rows.rowsReader = newRowsReader([]*as.Record{{Bins: result}})
+	return rows, nil
+}
@@ -0,0 +1,197 @@
+package aerospike
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/viant/sqlparser"
+	"github.com/viant/x"
+)
+
+// defaultInferSampleSize is the default number of records sampled to infer unregistered set schema
+const defaultInferSampleSize = 100
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// canInferSet returns true if unregistered set schema can be inferred, it is enabled with inferSchema=true DSN parameter
+// for select statements of sets without collection bin
+func (s *Statement) canInferSet() bool {
+	return s.cfg != nil && s.cfg.inferSchema && s.client != nil && s.kind == sqlparser.KindSelect && s.collectionBin == ""
+}
+
+// inferSet scans sample records of unregistered set and registers ad-hoc set type inferred from their bins,
+// inferred set can be unregistered with UNREGISTER SET to infer it again
+func (s *Statement) inferSet(ctx context.Context) (*set, error) {
+	records, err := s.sampleRecords(ctx, s.cfg.inferSampleSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to infer set %v schema: %w", s.set, err)
+	}
+	rType, rErr := inferRecordType(records)
+	if rErr != nil {
+		return nil, fmt.Errorf("unable to infer set %v schema: %w", s.set, rErr)
+	}
+	if rErr = s.sets.Register(&set{xType: x.NewType(rType, x.WithName(s.set))}); rErr != nil {
+		if aSet := s.sets.Lookup(s.set); aSet != nil { //registered concurrently
+			return aSet, nil
+		}
+		return nil, rErr
+	}
+	return s.sets.Lookup(s.set), nil
+}
+
+// sampleRecords scans up to sampleSize statement set records
+func (s *Statement) sampleRecords(ctx context.Context, sampleSize int) ([]*as.Record, error) {
+	policy := *s.client.DefaultScanPolicy
+	policy.MaxRecords = int64(sampleSize)
+	recordset, err := s.scanAllWithCtx(ctx, &policy, s.namespace, s.set, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer recordset.Close()
+	var records []*as.Record
+	for res := range recordset.Results() {
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		if res.Record == nil {
+			continue
+		}
+		if records = append(records, res.Record); len(records) >= sampleSize {
+			break
+		}
+	}
+	return records, nil
+}
+
+// inferRecordType returns struct type with a field per sampled bin, bins missing in some records use pointer types,
+// bins with inconsistent or collection values use interface{}, sets without id or pk bin get pk field of record user key
+// if every sampled record was written with SendKey write policy
+func inferRecordType(records []*as.Record) (reflect.Type, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no records sampled")
+	}
+	types := map[string]reflect.Type{}
+	counts := map[string]int{}
+	for _, record := range records {
+		for bin, value := range record.Bins {
+			if value == nil {
+				continue
+			}
+			counts[bin]++
+			if prev, ok := types[bin]; ok {
+				types[bin] = mergeInferredType(prev, inferValueType(value))
+			} else {
+				types[bin] = inferValueType(value)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("sampled records have no bins")
+	}
+	bins := make([]string, 0, len(types))
+	for bin := range types {
+		bins = append(bins, bin)
+	}
+	sort.Slice(bins, func(i, j int) bool {
+		if isKeyColumn(bins[i]) != isKeyColumn(bins[j]) {
+			return isKeyColumn(bins[i])
+		}
+		return bins[i] < bins[j]
+	})
+	fields := make([]reflect.StructField, 0, len(bins)+1)
+	names := map[string]bool{}
+	if !isKeyColumn(bins[0]) {
+		if keyType := inferKeyType(records); keyType != nil {
+			fields = append(fields, reflect.StructField{Name: "Pk", Type: keyType, Tag: `aerospike:"pk,pk=true"`})
+			names["Pk"] = true
+		}
+	}
+	for _, bin := range bins {
+		fieldType := types[bin]
+		if counts[bin] < len(records) && fieldType != interfaceType {
+			fieldType = reflect.PtrTo(fieldType)
+		}
+		name := inferredFieldName(bin)
+		for i := 2; names[name]; i++ {
+			name = fmt.Sprintf("%v%v", inferredFieldName(bin), i)
+		}
+		names[name] = true
+		fields = append(fields, reflect.StructField{Name: name, Type: fieldType, Tag: reflect.StructTag(fmt.Sprintf(`aerospike:"%v"`, bin))})
+	}
+	return reflect.StructOf(fields), nil
+}
+
+// inferKeyType returns Go type of sampled records user keys, or nil if some record was written without SendKey
+func inferKeyType(records []*as.Record) reflect.Type {
+	var result reflect.Type
+	for _, record := range records {
+		if record.Key == nil || record.Key.Value() == nil || record.Key.Value().GetObject() == nil {
+			return nil
+		}
+		keyType := inferValueType(record.Key.Value().GetObject())
+		if result != nil {
+			keyType = mergeInferredType(result, keyType)
+		}
+		result = keyType
+	}
+	return result
+}
+
+func isKeyColumn(bin string) bool {
+	bin = strings.ToLower(bin)
+	return bin == "id" || bin == "pk"
+}
+
+// inferValueType returns Go type of a bin value, collection and geo values use interface{}
+func inferValueType(value interface{}) reflect.Type {
+	switch value.(type) {
+	case int, int64:
+		return reflect.TypeOf(0)
+	case float64, float32:
+		return reflect.TypeOf(0.0)
+	case string:
+		return reflect.TypeOf("")
+	case bool:
+		return reflect.TypeOf(true)
+	case []byte:
+		return reflect.TypeOf([]byte{})
+	}
+	return interfaceType
+}
+
+func mergeInferredType(prev, next reflect.Type) reflect.Type {
+	if prev == next {
+		return prev
+	}
+	if isNumericKind(prev.Kind()) && isNumericKind(next.Kind()) {
+		return reflect.TypeOf(0.0)
+	}
+	return interfaceType
+}
+
+// inferredFieldName returns exported struct field name of a bin name
+func inferredFieldName(bin string) string {
+	var builder strings.Builder
+	upper := true
+	for _, r := range bin {
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	name := builder.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Bin" + name
+	}
+	return name
+}
@@ -0,0 +1,94 @@
+package aerospike
+
+import (
+	"database/sql/driver"
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"testing"
+)
+
+func Test_inferRecordType(t *testing.T) {
+	records := []*as.Record{
+		{Bins: as.BinMap{"id": 1, "name": "abc", "score": 1, "tags": []interface{}{"a"}, "last-login": "2024-01-01"}},
+		{Bins: as.BinMap{"id": 2, "name": "xyz", "score": 2.5, "tags": []interface{}{}, "data": []byte("x"), "flag": true}},
+		{Bins: as.BinMap{"id": 3, "name": 3, "score": 3, "tags": nil, "flag": false}},
+	}
+	rType, err := inferRecordType(records)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var expect = []struct {
+		name string
+		bin  string
+		typ  reflect.Type
+	}{
+		{name: "Id", bin: "id", typ: reflect.TypeOf(0)},
+		{name: "Data", bin: "data", typ: reflect.TypeOf(&[]byte{})},
+		{name: "Flag", bin: "flag", typ: reflect.TypeOf(new(bool))},
+		{name: "LastLogin", bin: "last-login", typ: reflect.TypeOf(new(string))},
+		{name: "Name", bin: "name", typ: interfaceType},
+		{name: "Score", bin: "score", typ: reflect.TypeOf(0.0)},
+		{name: "Tags", bin: "tags", typ: interfaceType},
+	}
+	if !assert.Equal(t, len(expect), rType.NumField()) {
+		return
+	}
+	for i, field := range expect {
+		assert.Equal(t, field.name, rType.Field(i).Name, field.bin)
+		assert.Equal(t, field.bin, rType.Field(i).Tag.Get("aerospike"), field.bin)
+		assert.Equal(t, field.typ, rType.Field(i).Type, field.bin)
+	}
+	aMapper, err := newTypeBasedMapper(rType)
+	if assert.Nil(t, err) && assert.Len(t, aMapper.pk, 1) {
+		assert.Equal(t, "id", aMapper.pk[0].Column())
+	}
+
+	_, err = inferRecordType(nil)
+	assert.NotNil(t, err, "no records")
+	assert.Equal(t, "Bin1st", inferredFieldName("1st"))
+}
+
+func Test_inferRecordType_userKey(t *testing.T) {
+	keys := make([]*as.Key, 2)
+	for i, value := range []string{"u1", "u2"} {
+		key, err := as.NewKey("test", "users", value)
+		if !assert.Nil(t, err) {
+			return
+		}
+		keys[i] = key
+	}
+	records := []*as.Record{
+		{Key: keys[0], Bins: as.BinMap{"name": "abc"}},
+		{Key: keys[1], Bins: as.BinMap{"name": "xyz"}},
+	}
+	rType, err := inferRecordType(records)
+	if !assert.Nil(t, err) || !assert.Equal(t, 2, rType.NumField()) {
+		return
+	}
+	assert.Equal(t, "pk,pk=true", rType.Field(0).Tag.Get("aerospike"))
+	assert.Equal(t, reflect.TypeOf(""), rType.Field(0).Type)
+	aMapper, err := newTypeBasedMapper(rType)
+	if !assert.Nil(t, err) || !assert.Len(t, aMapper.pk, 1) {
+		return
+	}
+	assert.Equal(t, "pk", aMapper.pk[0].Column())
+
+	record := reflect.New(rType).Interface()
+	rows := &Rows{mapper: aMapper, record: record, recordType: rType}
+	dest := make([]driver.Value, len(aMapper.fields))
+	if assert.Nil(t, rows.transferBinValues(dest, records[1], xunsafe.AsPointer(record))) {
+		assert.Equal(t, []driver.Value{"u2", "xyz"}, dest)
+	}
+
+	unsent, err := as.NewKeyWithDigest("test", "users", nil, keys[0].Digest())
+	if !assert.Nil(t, err) {
+		return
+	}
+	records[0].Key = unsent
+	rType, err = inferRecordType(records)
+	if assert.Nil(t, err) {
+		assert.Equal(t, 1, rType.NumField(), "record without user key")
+	}
+}
@@ -14,6 +14,8 @@ import (
 )
 
 func (s *Statement) prepareInsert(sql string, c *connection) error {
+	sql, s.returning = splitReturning(sql)
+	sql = quotePseudoColumns(sql)
 
 	if c.insertCache == nil {
 		parsed, err := sqlparser.ParseInsert(sql)
@@ -108,6 +110,7 @@ func (s *Statement) handleMapLoad(ctx context.Context, args []driver.NamedValue)
 
 	batchCount := s.insert.ValuesCnt() / len(s.insert.Columns)
 	var groups = make(map[interface{}][]map[interface{}]map[interface{}]interface{})
+	var expirations = make(map[interface{}]uint32)
 	argIndex := 0
 
 	for b := 0; b < batchCount; b++ {
@@ -116,6 +119,13 @@ func (s *Statement) handleMapLoad(ctx context.Context, args []driver.NamedValue)
 			return err
 		}
 		keyValue := s.getKey(s.mapper.pk, bins)
+		expiration, hasExpiration, err := s.popExpiration(bins)
+		if err != nil {
+			return err
+		}
+		if hasExpiration {
+			expirations[keyValue] = expiration
+		}
 		group, ok := groups[keyValue]
 		if !ok {
 			group = make([]map[interface{}]map[interface{}]interface{}, 0)
@@ -144,7 +154,7 @@ func (s *Statement) handleMapLoad(ctx context.Context, args []driver.NamedValue)
 
 	isMerge := len(s.insert.OnDuplicateKeyUpdate) > 0
 	if isMerge {
-		return s.handleMapMerge(ctx, groups)
+		return s.handleMapMerge(ctx, groups, expirations)
 	}
 
 	aSet, err := s.lookupSet()
@@ -153,54 +163,67 @@ func (s *Statement) handleMapLoad(ctx context.Context, args []driver.NamedValue)
 	}
 
 	for keyValue, groupSet := range groups {
-		for _, group := range groupSet {
-			key, err := as.NewKey(s.namespace, s.set, keyValue)
-			if err != nil {
-				return err
-			}
-
-			writePolicy := s.writePolicy(aSet, true)
-
-			var ops []*as.Operation
-			mapPolicy := as.DefaultMapPolicy()
-			var values = make(map[interface{}]interface{}, len(group))
-			if s.mapper.component != nil {
-				if err := s.ensureMapOfSlice(ctx, group, key); err != nil {
+		for _, logicalGroup := range groupSet {
+			for recordKey, group := range aSet.shardGroups(keyValue, logicalGroup) {
+				if err := s.loadMapGroup(ctx, aSet, keyValue, recordKey, group, expirations); err != nil {
 					return err
 				}
-				for k, v := range group {
-					indexLiteral, ok := v[s.mapper.arrayIndex.Column()]
-					if !ok {
-						return fmt.Errorf("unable to find list secondaryIndex")
-					}
-					idx, ok := indexLiteral.(int)
-					if !ok {
-						return fmt.Errorf("invalid list secondaryIndex: %v", indexLiteral)
-					}
-					componentValue, ok := v[s.mapper.component.Column()]
-					key := as.CtxMapKey(as.NewValue(k))
-					ops = append(ops, as.ListSetOp(s.collectionBin, idx, as.NewValue(componentValue), key))
-				}
+			}
+		}
+	}
+	return nil
+}
 
-				if _, err = s.operateWithCtx(ctx, writePolicy, key, ops); err != nil {
-					return err
-				}
-				continue
-			} else {
+// loadMapGroup writes map entries group into the pk record or its sub-record identified by record key value
+func (s *Statement) loadMapGroup(ctx context.Context, aSet *set, keyValue, recordKey interface{}, group map[interface{}]map[interface{}]interface{}, expirations map[interface{}]uint32) error {
+	key, err := as.NewKey(s.namespace, s.set, recordKey)
+	if err != nil {
+		return err
+	}
 
-				for k, v := range group {
-					// v is bins map for a single entry; convert to entry value (object or scalar)
-					entry := s.buildMapEntryValueFromIfaceMap(v)
-					values[k] = entry
-				}
-				ops = append(ops, as.MapPutItemsOp(mapPolicy, s.collectionBin, values))
+	writePolicy := s.writePolicy(aSet, true)
+	if expiration, ok := expirations[keyValue]; ok {
+		writePolicy.Expiration = expiration
+	}
+
+	ops := s.shardPkOperations(aSet, keyValue)
+	mapPolicy := aSet.mapWritePolicy()
+	var values = make(map[interface{}]interface{}, len(group))
+	if s.mapper.component != nil {
+		if err := s.ensureMapOfSlice(ctx, group, key); err != nil {
+			return err
+		}
+		for k, v := range group {
+			indexLiteral, ok := v[s.mapper.arrayIndex.Column()]
+			if !ok {
+				return fmt.Errorf("unable to find list secondaryIndex")
 			}
-			if _, err = s.operateWithCtx(ctx, writePolicy, key, ops); err != nil {
-				return err
+			idx, ok := indexLiteral.(int)
+			if !ok {
+				return fmt.Errorf("invalid list secondaryIndex: %v", indexLiteral)
 			}
+			componentValue, ok := v[s.mapper.component.Column()]
+			key := as.CtxMapKey(as.NewValue(k))
+			ops = append(ops, as.ListSetOp(s.collectionBin, idx, as.NewValue(componentValue), key))
 		}
+		_, err = s.operateWithCtx(ctx, writePolicy, key, ops)
+		return err
 	}
-	return nil
+
+	var mapKeys []interface{}
+	for k, v := range group {
+		// v is bins map for a single entry; convert to entry value (object or scalar)
+		entry := s.buildMapEntryValueFromIfaceMap(v)
+		values[k] = entry
+		mapKeys = append(mapKeys, k)
+	}
+	ops = append(ops, as.MapPutItemsOp(mapPolicy, s.collectionBin, values))
+	ops = s.appendReturningMapEntries(writePolicy, ops, mapKeys)
+	result, err := s.operateWithCtx(ctx, writePolicy, key, ops)
+	if err != nil {
+		return err
+	}
+	return s.addReturnedMapEntries(result, keyValue, mapKeys)
 }
 
 func (s *Statement) ensureMapOfSlice(ctx context.Context, group map[interface{}]map[interface{}]interface{}, key *as.Key) error {
@@ -208,7 +231,7 @@ func (s *Statement) ensureMapOfSlice(ctx context.Context, group map[interface{}]
 	if err != nil {
 		return err
 	}
-	newEntryPolicy := as.NewMapPolicyWithFlags(as.MapOrder.UNORDERED, as.MapWriteFlagsCreateOnly|as.MapWriteFlagsNoFail)
+	newEntryPolicy := aSet.mapPolicy(as.MapWriteFlagsCreateOnly | as.MapWriteFlagsNoFail)
 	var ops []*as.Operation
 	var values = map[interface{}]interface{}{}
 	for mapKey, _ := range group {
@@ -224,12 +247,12 @@ func (s *Statement) ensureMapOfSlice(ctx context.Context, group map[interface{}]
 	return nil
 }
 
-func (s *Statement) handleMapMerge(ctx context.Context, groups map[interface{}][]map[interface{}]map[interface{}]interface{}) error {
+func (s *Statement) handleMapMerge(ctx context.Context, groups map[interface{}][]map[interface{}]map[interface{}]interface{}, expirations map[interface{}]uint32) error {
 	addColumn, subColumn, err := s.identifyAddSubColumn()
 	if s.cfg.concurrency <= 1 {
 		for recKey := range groups {
 			groupSet := groups[recKey]
-			if e := s.mergeMaps(ctx, recKey, groupSet, addColumn, subColumn); e != nil {
+			if e := s.mergeMaps(ctx, recKey, groupSet, addColumn, subColumn, expirations); e != nil {
 				err = e
 			}
 		}
@@ -250,7 +273,7 @@ func (s *Statement) handleMapMerge(ctx context.Context, groups map[interface{}][
 				wg.Done()
 				<-rateLimiter
 			}()
-			if e := s.mergeMaps(ctx, recKey, groupSet, addColumn, subColumn); e != nil {
+			if e := s.mergeMaps(ctx, recKey, groupSet, addColumn, subColumn, expirations); e != nil {
 				err = e
 			}
 		}(key, groupSet)
@@ -259,9 +282,24 @@ func (s *Statement) handleMapMerge(ctx context.Context, groups map[interface{}][
 	return err
 }
 
-func (s *Statement) mergeMaps(ctx context.Context, recKey interface{}, groupSet []map[interface{}]map[interface{}]interface{}, addColumn map[string]bool, subColumn map[string]bool) error {
-	var err error
-	key, err := as.NewKey(s.namespace, s.set, recKey)
+func (s *Statement) mergeMaps(ctx context.Context, recKey interface{}, groupSet []map[interface{}]map[interface{}]interface{}, addColumn map[string]bool, subColumn map[string]bool, expirations map[interface{}]uint32) error {
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return err
+	}
+	for _, logicalGroup := range groupSet {
+		for recordKey, group := range aSet.shardGroups(recKey, logicalGroup) {
+			if err = s.mergeMapGroup(ctx, aSet, recKey, recordKey, group, addColumn, subColumn, expirations); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeMapGroup merges map entries group into the pk record or its sub-record identified by record key value
+func (s *Statement) mergeMapGroup(ctx context.Context, aSet *set, recKey, recordKey interface{}, group map[interface{}]map[interface{}]interface{}, addColumn map[string]bool, subColumn map[string]bool, expirations map[interface{}]uint32) error {
+	key, err := as.NewKey(s.namespace, s.set, recordKey)
 	if err != nil {
 		return err
 	}
@@ -271,79 +309,79 @@ func (s *Statement) mergeMaps(ctx context.Context, recKey interface{}, groupSet
 		slice = s.mapper.newSlice()
 	}
 
-	for _, group := range groupSet {
-		var ops []*as.Operation
-		var createOp []*as.Operation
+	ops := s.shardPkOperations(aSet, recKey)
+	var createOp []*as.Operation
+	var mapKeys []interface{}
 
-		for groupKey, bins := range group {
-			mapKey := as.CtxMapKey(as.NewValue(groupKey))
-			createOnly := as.NewMapPolicyWithFlags(as.MapOrder.UNORDERED, as.MapWriteFlagsCreateOnly|as.MapWriteFlagsNoFail)
-			if s.mapper.component != nil {
-				createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, groupKey, slice))
-			} else {
-				createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, groupKey, map[interface{}]interface{}{}))
+	for groupKey, bins := range group {
+		mapKeys = append(mapKeys, groupKey)
+		mapKey := as.CtxMapKey(as.NewValue(groupKey))
+		createOnly := aSet.mapPolicy(as.MapWriteFlagsCreateOnly | as.MapWriteFlagsNoFail)
+		if s.mapper.component != nil {
+			createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, groupKey, slice))
+		} else {
+			createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, groupKey, map[interface{}]interface{}{}))
+		}
+
+		if s.mapper.component != nil {
+			key := as.CtxMapKey(as.NewValue(groupKey))
+			arrayKey := bins[s.mapper.arrayIndex.Column()]
+			idx := arrayKey.(int)
+			componentValue, ok := bins[s.mapper.component.Column()]
+			if !ok {
+				return fmt.Errorf("unable to find component value")
 			}
 
-			if s.mapper.component != nil {
-				key := as.CtxMapKey(as.NewValue(groupKey))
-				arrayKey := bins[s.mapper.arrayIndex.Column()]
-				idx := arrayKey.(int)
-				componentValue, ok := bins[s.mapper.component.Column()]
-				if !ok {
-					return fmt.Errorf("unable to find component value")
+			if addColumn[s.mapper.component.Column()] {
+				ops = append(ops, as.ListIncrementOp(s.collectionBin, idx, as.NewValue(componentValue), key))
+			} else if subColumn[s.mapper.component.Column()] {
+				switch actual := componentValue.(type) {
+				case int64:
+					componentValue = -actual
+				case int:
+					componentValue = -actual
+				case float64:
+					componentValue = -actual
 				}
+				ops = append(ops, as.ListIncrementOp(s.collectionBin, idx, as.NewValue(componentValue), key))
+			} else {
+				ops = append(ops, as.ListSetOp(s.collectionBin, idx, as.NewValue(componentValue), key))
+			}
 
-				if addColumn[s.mapper.component.Column()] {
-					ops = append(ops, as.ListIncrementOp(s.collectionBin, idx, as.NewValue(componentValue), key))
-				} else if subColumn[s.mapper.component.Column()] {
-					switch actual := componentValue.(type) {
-					case int64:
-						componentValue = -actual
-					case int:
-						componentValue = -actual
-					case float64:
-						componentValue = -actual
-					}
-					ops = append(ops, as.ListIncrementOp(s.collectionBin, idx, as.NewValue(componentValue), key))
+		} else {
+			mapPolicy := aSet.mapPolicy(as.MapWriteFlagsDefault)
+
+			for col, value := range bins {
+				column := col.(string)
+				columnValue := as.NewStringValue(column)
+
+				if addColumn[column] {
+					createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, columnValue, s.mapper.columnZeroValue(column), mapKey))
+					ops = append(ops, as.MapIncrementOp(mapPolicy, s.collectionBin, columnValue, value, mapKey))
+				} else if subColumn[column] {
+					createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, columnValue, s.mapper.columnZeroValue(column), mapKey))
+					ops = append(ops, as.MapDecrementOp(mapPolicy, s.collectionBin, columnValue, value, mapKey))
 				} else {
-					ops = append(ops, as.ListSetOp(s.collectionBin, idx, as.NewValue(componentValue), key))
-				}
-
-			} else {
-				mapPolicy := as.DefaultMapPolicy()
-
-				for col, value := range bins {
-					column := col.(string)
-					columnValue := as.NewStringValue(column)
-
-					if addColumn[column] {
-						createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, columnValue, s.mapper.columnZeroValue(column), mapKey))
-						ops = append(ops, as.MapIncrementOp(mapPolicy, s.collectionBin, columnValue, value, mapKey))
-					} else if subColumn[column] {
-						createOp = append(createOp, as.MapPutOp(createOnly, s.collectionBin, columnValue, s.mapper.columnZeroValue(column), mapKey))
-						ops = append(ops, as.MapDecrementOp(mapPolicy, s.collectionBin, columnValue, value, mapKey))
-					} else {
-						ops = append(ops, as.MapPutOp(mapPolicy, s.collectionBin, columnValue, value, mapKey))
-					}
+					ops = append(ops, as.MapPutOp(mapPolicy, s.collectionBin, columnValue, value, mapKey))
 				}
-
 			}
-		}
-		aSet, err := s.lookupSet()
-		if err != nil {
-			return err
-		}
-		writePolicy := s.writePolicy(aSet, true)
 
-		if _, err = s.operateWithCtx(ctx, writePolicy, key, createOp); err != nil {
-			return err
-		}
-		if _, err = s.operateWithCtx(ctx, writePolicy, key, ops); err != nil {
-			return err
 		}
 	}
+	writePolicy := s.writePolicy(aSet, true)
+	if expiration, ok := expirations[recKey]; ok {
+		writePolicy.Expiration = expiration
+	}
 
-	return nil
+	if _, err = s.operateWithCtx(ctx, writePolicy, key, createOp); err != nil {
+		return err
+	}
+	ops = s.appendReturningMapEntries(writePolicy, ops, mapKeys)
+	result, err := s.operateWithCtx(ctx, writePolicy, key, ops)
+	if err != nil {
+		return err
+	}
+	return s.addReturnedMapEntries(result, recKey, mapKeys)
 }
 
 func (s *Statement) identifyAddSubColumn() (map[string]bool, map[string]bool, error) {
@@ -388,6 +426,7 @@ func (s *Statement) handleListInsert(ctx context.Context, args []driver.NamedVal
 	if err != nil {
 		return err
 	}
+	var expirations = map[interface{}]uint32{}
 	for i := 0; i < itemCount; i++ {
 		bins, err := s.populateInsertBins(args, &argIndex)
 		if err != nil {
@@ -395,15 +434,28 @@ func (s *Statement) handleListInsert(ctx context.Context, args []driver.NamedVal
 		}
 		keyValue := s.getKey(s.mapper.pk, bins)
 		delete(bins, s.mapper.pk[0].Column())
-		operations[keyValue] = append(operations[keyValue], as.ListAppendOp(s.collectionBin, bins))
+		expiration, ok, err := s.popExpiration(bins)
+		if err != nil {
+			return err
+		}
+		if ok {
+			expirations[keyValue] = expiration
+		}
+		operations[keyValue] = append(operations[keyValue], as.ListAppendWithPolicyOp(aSet.listPolicy(), s.collectionBin, bins))
 	}
 	for keyValue, operations := range operations {
 		key, err := as.NewKey(s.namespace, s.set, keyValue)
 		if err != nil {
 			return err
 		}
+		if s.mapper.arraySize > 0 {
+			operations = append(operations, listBoundOperation(s.collectionBin, s.mapper.arraySize))
+		}
 		operations = append(operations, as.PutOp(as.NewBin(s.mapper.pk[0].Column(), keyValue)))
 		writePolicy := s.writePolicy(aSet, true)
+		if expiration, ok := expirations[keyValue]; ok {
+			writePolicy.Expiration = expiration
+		}
 
 		ret, err := s.operateWithCtx(ctx, writePolicy, key, operations)
 		if err != nil {
@@ -413,18 +465,46 @@ func (s *Statement) handleListInsert(ctx context.Context, args []driver.NamedVal
 		if !ok {
 			return fmt.Errorf("failed to insert list value")
 		}
-		switch actual := rawSize.(type) {
-		case int:
-			lastInsertID := int64(actual - 1)
-			s.lastInsertID = &lastInsertID
-		case []interface{}:
-			lastInsertID := int64(actual[len(actual)-1].(int))
+		if lastInsertID, ok := listLastInsertID(rawSize, s.mapper.arraySize); ok {
 			s.lastInsertID = &lastInsertID
 		}
 	}
 	return nil
 }
 
+// listLastInsertID returns last insert id of list append result, single row insert returns index of the appended item,
+// multi row insert returns list size after the last append, sizes of bounded list are capped at arraySize,
+// bound operation results are skipped
+func listLastInsertID(rawSize interface{}, arraySize int) (int64, bool) {
+	switch actual := rawSize.(type) {
+	case int:
+		return int64(boundedListSize(actual, arraySize) - 1), true
+	case []interface{}:
+		var sizes []int
+		for _, item := range actual {
+			if size, ok := item.(int); ok {
+				sizes = append(sizes, size)
+			}
+		}
+		switch len(sizes) {
+		case 0:
+			return 0, false
+		case 1:
+			return int64(boundedListSize(sizes[0], arraySize) - 1), true
+		}
+		return int64(boundedListSize(sizes[len(sizes)-1], arraySize)), true
+	}
+	return 0, false
+}
+
+// boundedListSize returns list size after bound operation trims list to the last arraySize items
+func boundedListSize(size, arraySize int) int {
+	if arraySize > 0 && size > arraySize {
+		return arraySize
+	}
+	return size
+}
+
 func (s *Statement) handleInsert(ctx context.Context, args []driver.NamedValue) error {
 	if s.insert == nil {
 		return fmt.Errorf("insert statement is not initialized")
@@ -443,6 +523,9 @@ func (s *Statement) handleInsert(ctx context.Context, args []driver.NamedValue)
 		s.writeLimiter.acquire()
 	}
 
+	if err := s.ensureReturningSupported(); err != nil {
+		return err
+	}
 	s.affected = int64(batchCount)
 	if isDryRun("insert") {
 		return nil
@@ -475,34 +558,124 @@ func (s *Statement) handleInsert(ctx context.Context, args []driver.NamedValue)
 			return err
 		}
 		writePolicy := s.writePolicy(aSet, true)
+		generation, hasGeneration, err := s.popGeneration(bins)
+		if err != nil {
+			return err
+		}
+		if expiration, ok, err := s.popExpiration(bins); err != nil {
+			return err
+		} else if ok {
+			writePolicy.Expiration = expiration
+		}
 
 		if s.collectionBin != "" {
-			return s.handleMapInsert(ctx, bins, err, writePolicy, key)
+			if !hasGeneration {
+				return s.handleMapInsert(ctx, bins, err, writePolicy, key)
+			}
+			expectGeneration(writePolicy, generation)
+			return generationError(s.handleMapInsert(ctx, bins, err, writePolicy, key), key, generation)
+		}
+		if !isMerge || hasGeneration {
+			expectGeneration(writePolicy, generation)
+		}
+		chunks, err := s.writeChunks(ctx, key, bins, writePolicy)
+		if err != nil {
+			return err
 		}
 		if isMerge {
-			if err := s.handleMerge(ctx, bins, writePolicy, key); err != nil {
+			if err := s.completeChunks(ctx, chunks, s.handleMerge(ctx, bins, writePolicy, key)); err != nil {
+				return generationError(err, key, generation)
+			}
+			if err := s.renewChunks(ctx, key, bins, writePolicy.Expiration); err != nil {
 				return err
 			}
 			continue
 		}
 
-		writePolicy.GenerationPolicy = as.EXPECT_GEN_EQUAL
-		if err = s.putWithCtx(ctx, writePolicy, key, bins); err != nil {
+		if len(s.returning) > 0 {
+			err = s.handlePutReturning(ctx, bins, writePolicy, key)
+		} else {
+			err = s.putWithCtx(ctx, writePolicy, key, bins)
+		}
+		if err = s.completeChunks(ctx, chunks, err); err != nil {
+			if hasGeneration {
+				return generationError(err, key, generation)
+			}
 			return err
 		}
 	}
 	return nil
 }
 
+// popGeneration removes generation column from bins and returns its value
+func (s *Statement) popGeneration(bins map[string]interface{}) (uint32, bool, error) {
+	value, ok := s.popMetaValue(bins, s.mapper.generation, generationColumn)
+	if !ok {
+		return 0, false, nil
+	}
+	generation, err := asGeneration(value)
+	return generation, err == nil, err
+}
+
+// popExpiration removes ttl column from bins and returns record expiration
+func (s *Statement) popExpiration(bins map[string]interface{}) (uint32, bool, error) {
+	value, ok := s.popMetaValue(bins, s.mapper.ttl, ttlColumn)
+	if !ok {
+		return 0, false, nil
+	}
+	return asExpiration(value)
+}
+
+// popMetaValue removes record metadata value, defined either by tagged field or pseudo column, from bins
+func (s *Statement) popMetaValue(bins map[string]interface{}, aField *field, pseudoColumn string) (interface{}, bool) {
+	value, ok := bins[pseudoColumn]
+	delete(bins, pseudoColumn)
+	if aField != nil {
+		if fieldValue, has := bins[aField.Column()]; has {
+			delete(bins, aField.Column())
+			if !ok {
+				value, ok = fieldValue, true
+			}
+		}
+	}
+	return value, ok
+}
+
 func (s *Statement) handleMapInsert(ctx context.Context, bins map[string]interface{}, err error, writePolicy *as.WritePolicy, key *as.Key) error {
 	mapKey := s.getKey(s.mapper.mapKey, bins)
 	// Collapse bins into a single entry value (scalar or object) excluding pk/mapKey/index
 	entry := s.buildMapEntryValueFromStringMap(bins)
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return err
+	}
 	ops := []*as.Operation{
-		as.MapPutOp(as.DefaultMapPolicy(), s.collectionBin, mapKey, entry),
+		as.MapPutOp(aSet.mapWritePolicy(), s.collectionBin, mapKey, entry),
 	}
-	_, err = s.operateWithCtx(ctx, writePolicy, key, ops)
-	return err
+	mapKeys := []interface{}{mapKey}
+	ops = s.appendReturningMapEntries(writePolicy, ops, mapKeys)
+	result, err := s.operateWithCtx(ctx, writePolicy, key, ops)
+	if err != nil {
+		return err
+	}
+	return s.addReturnedMapEntries(result, key.Value().GetObject(), mapKeys)
+}
+
+// handlePutReturning writes bins with operate call to read back RETURNING bins
+func (s *Statement) handlePutReturning(ctx context.Context, bins map[string]interface{}, writePolicy *as.WritePolicy, key *as.Key) error {
+	ops := make([]*as.Operation, 0, len(bins))
+	written := make(map[string]bool, len(bins))
+	for column, value := range bins {
+		ops = append(ops, as.PutOp(as.NewBin(column, value)))
+		written[column] = true
+	}
+	ops = s.appendReturning(writePolicy, ops)
+	result, err := s.operateWithCtx(ctx, writePolicy, key, ops)
+	if err != nil {
+		return err
+	}
+	s.addReturned(result, written)
+	return nil
 }
 
 func (s *Statement) handleMerge(ctx context.Context, bins map[string]interface{}, writePolicy *as.WritePolicy, key *as.Key) error {
@@ -520,15 +693,20 @@ func (s *Statement) handleMerge(ctx context.Context, bins map[string]interface{}
 			ops = append(ops, as.PutOp(as.NewBin(column, value)))
 		}
 	}
-	_, err = s.operateWithCtx(ctx, writePolicy, key, ops)
-	return err
+	ops = s.appendReturning(writePolicy, ops)
+	result, err := s.operateWithCtx(ctx, writePolicy, key, ops)
+	if err != nil {
+		return err
+	}
+	s.addReturned(result, writtenBins(bins))
+	return nil
 }
 
 func (s *Statement) populateInsertBins(args []driver.NamedValue, argIndex *int) (map[string]interface{}, error) {
 	bins := make(map[string]interface{})
 	for i, column := range s.insert.Columns {
 		aField := s.mapper.getField(column)
-		if aField == nil {
+		if aField == nil && !isPseudoColumn(column) {
 			return nil, fmt.Errorf("unable to find field %v in type %T", column, s.recordType)
 		}
 		columnValue := s.insert.ValueAt(i)
@@ -543,10 +721,29 @@ func (s *Statement) populateInsertBins(args []driver.NamedValue, argIndex *int)
 			}
 			value = val.Value
 		}
+		if aField == nil {
+			bins[strings.ToLower(strings.Trim(column, "`"))] = value
+			continue
+		}
+		if aField.tag.OmitEmpty && columnValue.IsPlaceholder() && isEmptyValue(value) {
+			continue
+		}
+		if isNullValue(value) {
+			bins[aField.Column()] = nil
+			continue
+		}
 		value, err := aField.ensureValidValueType(value)
 		if err != nil {
+			if aField.tag.Codec != "" {
+				return nil, codecError(s.set, aField, err)
+			}
 			return nil, err
 		}
+		if aField.tag.IsEncrypted {
+			if value, err = s.encrypt(aField, value); err != nil {
+				return nil, err
+			}
+		}
 		// Normalize custom byte-slice types (e.g., json.RawMessage) to []byte for Aerospike
 		if value != nil {
 			v := reflect.ValueOf(value)
@@ -583,7 +780,7 @@ func (s *Statement) buildMapEntryValueFromIfaceMap(bins map[interface{}]interfac
 		if s.mapper.arrayIndex != nil && col == s.mapper.arrayIndex.Column() {
 			skip = true
 		}
-		if !skip {
+		if !skip && v != nil {
 			obj[k] = v
 		}
 	}
@@ -360,3 +360,25 @@ func Test_isPlaceholderList_02(t *testing.T) {
 	isValid := isPlaceholderList("(?, ?, ?), (?, ?, ?)")
 	assert.True(t, isValid)
 }
+
+func Test_listLastInsertID(t *testing.T) {
+	var testCases = []struct {
+		description string
+		rawSize     interface{}
+		arraySize   int
+		expect      int64
+		expectOk    bool
+	}{
+		{description: "single row", rawSize: 3, expect: 2, expectOk: true},
+		{description: "bounded single row", rawSize: []interface{}{5, nil}, arraySize: 3, expect: 2, expectOk: true},
+		{description: "bounded single row size", rawSize: 5, arraySize: 3, expect: 2, expectOk: true},
+		{description: "multi row", rawSize: []interface{}{2, 3, 4}, expect: 4, expectOk: true},
+		{description: "bounded multi row", rawSize: []interface{}{3, 4, nil}, arraySize: 3, expect: 3, expectOk: true},
+		{description: "no size", rawSize: []interface{}{nil}},
+	}
+	for _, testCase := range testCases {
+		actual, ok := listLastInsertID(testCase.rawSize, testCase.arraySize)
+		assert.Equal(t, testCase.expectOk, ok, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
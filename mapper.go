@@ -1,6 +1,7 @@
 package aerospike
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -29,7 +30,10 @@ type (
 		index    int
 		isPseudo bool
 		isFunc   bool
+		isMeta   bool
 		value    interface{}
+		path     []*field
+		bin      string
 	}
 
 	mapper struct {
@@ -39,11 +43,14 @@ type (
 		arrayIndex       *field
 		secondaryIndex   *field
 		component        *field
+		generation       *field
+		ttl              *field
 		arraySize        int
 		byName           map[string]int
 		columnList       map[string]bool
 		pseudoColumns    map[string]interface{}
 		aggregateColumn  map[string]*expr.Call
+		binFunctions     map[string]*binFunction
 		columnZeroValues map[string]interface{}
 		groupBy          []int
 		zeroMux          sync.RWMutex
@@ -51,10 +58,57 @@ type (
 )
 
 func (f *field) ensureValidValueType(value interface{}) (interface{}, error) {
+	if f.tag != nil && f.tag.Codec != "" {
+		encoded, err := f.encode(value)
+		if err != nil || encoded == nil || f.tag.Compress == "" {
+			return encoded, err
+		}
+		return f.compress(encoded)
+	}
+	value, err := f.ensureValueType(value)
+	if err != nil || f.tag == nil || f.tag.Compress == "" {
+		return value, err
+	}
+	return f.compress(value)
+}
+
+func (f *field) ensureValueType(value interface{}) (interface{}, error) {
 
 	if iFacePtr, ok := value.(*interface{}); ok && iFacePtr != nil {
 		value = *iFacePtr
 	}
+	if f.tag != nil && f.tag.IsDecimal {
+		scaled, err := encodeDecimal(value, f.tag.Scale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v decimal: %w", f.Column(), err)
+		}
+		return scaled, nil
+	}
+	if conv := lookupConverter(f.Type); conv != nil && conv.toBin != nil {
+		return conv.convertToBin(value)
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil, nil
+		}
+		driverValue, err := valuer.Value()
+		if err != nil {
+			return nil, fmt.Errorf("unable to ensure valid value type due to: %w", err)
+		}
+		if driverValue == nil {
+			return nil, nil
+		}
+		return f.ensureValueType(driverValue)
+	}
+	if f.tag.hasTimeEncoding() && isTimeType(f.Type) {
+		return f.tag.encodeTimeValue(value)
+	}
+	if isNestedStruct(f.Type) {
+		return f.encodeNested(value)
+	}
+	if hasTypedElements(f.Type) {
+		return encodeTyped(value)
+	}
 	valueType := reflect.TypeOf(value)
 	if valueType == nil {
 		valueType = f.Type
@@ -63,7 +117,7 @@ func (f *field) ensureValidValueType(value interface{}) (interface{}, error) {
 	// If both the incoming value and field types are pointers but with different depths,
 	// unwrap the incoming value pointers (e.g., **bool -> *bool or bool) to avoid passing
 	// unsupported pointer types to the Aerospike client. Skip special time handling to
-	// preserve existing UnixSec conversions.
+	// preserve time pointer values.
 	if value != nil && valueType.Kind() == reflect.Ptr && f.Type.Kind() == reflect.Ptr && valueType != f.Type {
 		// Avoid unwrapping time pointers here; they are handled explicitly below.
 		if f.Type != timePtrType && f.Type != timeDoublePtrType {
@@ -89,34 +143,6 @@ func (f *field) ensureValidValueType(value interface{}) (interface{}, error) {
 				}
 			}
 		}
-		if valueType == timeType {
-			v, ok := value.(time.Time)
-			if !ok {
-				return nil, fmt.Errorf("unable to ensure valid value type - invalid type %T expected %T", value, v)
-			}
-			if f.tag.UnixSec {
-				value = v.Unix()
-			}
-		}
-		if valueType == timePtrType {
-			v, ok := value.(*time.Time)
-			if !ok {
-				return nil, fmt.Errorf("unable to ensure valid value type - invalid type %T expected %T", value, v)
-			}
-			if f.tag.UnixSec {
-				value = v.Unix()
-			}
-		}
-		if valueType == timeDoublePtrType {
-			v, ok := value.(**time.Time)
-			if !ok {
-				return nil, fmt.Errorf("unable to ensure valid value type - invalid type %T expected %T", value, v)
-			}
-			if f.tag.UnixSec {
-				vv := *v
-				value = vv.Unix()
-			}
-		}
 		return value, nil
 	}
 
@@ -127,13 +153,8 @@ func (f *field) ensureValidValueType(value interface{}) (interface{}, error) {
 		// TODO add extra converson logic
 		// TODO check pointers
 		if f.Type == timeType && valueType.Kind() == reflect.String {
-			v, err := time.Parse(time.RFC3339, value.(string))
-			if err != nil {
-				return nil, fmt.Errorf("unable to ensure valid value type due to: %w", err)
-			}
-
-			if f.tag.UnixSec {
-				value = v.Unix()
+			if _, err := f.tag.parseTime(value.(string)); err != nil {
+				return nil, err
 			}
 		} else if valueType.Kind() == reflect.Ptr {
 			var err error
@@ -145,27 +166,8 @@ func (f *field) ensureValidValueType(value interface{}) (interface{}, error) {
 
 		basicType := baseType(f.Type)
 		valueType = reflect.TypeOf(value)
-		if basicType.Kind() != valueType.Kind() {
-			b := basicType.Kind()
-
-			switch t := valueType.Kind(); t {
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				switch b {
-				case reflect.Float32:
-					value = float32(value.(int))
-				case reflect.Float64:
-					value = float64(value.(int))
-				}
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				switch b {
-				case reflect.Float32:
-					value = float32(value.(uint))
-				case reflect.Float64:
-					value = float64(value.(uint))
-				}
-			case reflect.Float32, reflect.Float64:
-				return nil, fmt.Errorf("unable to ensure valid type: can't convert value %v (%v) to type %v", value, t, b)
-			}
+		if basicType.Kind() != valueType.Kind() && isNumericKind(basicType.Kind()) && isNumericKind(valueType.Kind()) {
+			return coerceNumber(value, kindTypes[basicType.Kind()])
 		}
 	}
 
@@ -289,6 +291,12 @@ func extractKeyValue(value interface{}) (interface{}, error) {
 }
 
 func (f *field) Column() string {
+	if len(f.path) > 0 {
+		return f.path[0].Column()
+	}
+	if f.bin != "" {
+		return f.bin
+	}
 	if f.tag != nil {
 		return f.tag.Name
 	}
@@ -306,6 +314,9 @@ func (m *mapper) expandBins(extraBins ...string) []string {
 	bins := make([]string, 0, len(m.fields))
 	unique := map[string]bool{}
 	for _, field := range m.fields {
+		if field.isMeta || field.tag.IsGeneration || field.tag.IsTTL {
+			continue
+		}
 		bins = append(bins, field.Column())
 		unique[field.Column()] = true
 	}
@@ -350,6 +361,12 @@ func (m *mapper) addField(aField reflect.StructField, tag *Tag) *field {
 	if tag.IsComponent {
 		m.component = mapperField
 	}
+	if tag.IsGeneration {
+		m.generation = mapperField
+	}
+	if tag.IsTTL {
+		m.ttl = mapperField
+	}
 	if tag.ArraySize > 0 {
 		m.arraySize = tag.ArraySize
 	}
@@ -376,10 +393,13 @@ func newQueryMapper(recordType reflect.Type, aQuery *query.Select, typeMapper *m
 		arraySize:       typeMapper.arraySize,
 		secondaryIndex:  typeMapper.secondaryIndex,
 		component:       typeMapper.component,
+		generation:      typeMapper.generation,
+		ttl:             typeMapper.ttl,
 		mapKey:          typeMapper.mapKey,
 		pk:              typeMapper.pk,
 		pseudoColumns:   make(map[string]interface{}),
 		aggregateColumn: make(map[string]*expr.Call),
+		binFunctions:    make(map[string]*binFunction),
 		groupBy:         []int{},
 	}
 	for i := 0; i < len(list); i++ {
@@ -416,15 +436,24 @@ func newQueryMapper(recordType reflect.Type, aQuery *query.Select, typeMapper *m
 				return nil, err
 			}
 		case *expr.Call:
-			funName := sqlparser.Stringify(actual.X)
-			switch strings.ToLower(funName) {
-			case "count":
+			funName := strings.ToLower(sqlparser.Stringify(actual.X))
+			switch {
+			case funName == "count":
 				if item.Alias == "" {
 					item.Alias = "t" + strconv.Itoa(i)
 				}
 				ret.aggregateColumn[item.Alias] = actual
+			case isBinFunction(funName):
+				if item.Alias == "" {
+					item.Alias = "t" + strconv.Itoa(i)
+				}
+				function, err := newBinFunction(item.Alias, actual, typeMapper)
+				if err != nil {
+					return nil, err
+				}
+				ret.binFunctions[item.Alias] = function
 			}
-			if err := ret.appendField(recordType, item.Alias, typeMapper, false, true, reflect.TypeOf(0)); err != nil {
+			if err := ret.appendField(recordType, item.Alias, typeMapper, false, true, binFunctionType(funName)); err != nil {
 				return nil, err
 			}
 		default:
@@ -459,9 +488,24 @@ func newQueryMapper(recordType reflect.Type, aQuery *query.Select, typeMapper *m
 }
 
 func (m *mapper) appendField(recordType reflect.Type, name string, typeMapper *mapper, pseudo bool, fun bool, rType reflect.Type) error {
+	if path := typeMapper.lookupPath(name); !pseudo && !fun && path != nil {
+		leaf := path[len(path)-1]
+		m.fields = append(m.fields,
+			field{
+				Field: &xunsafe.Field{
+					Name: leaf.Name,
+					Type: leaf.Type,
+				},
+				tag:  &Tag{Name: pathName(path)},
+				path: path,
+			})
+		m.byName[pathName(path)] = len(m.fields) - 1
+		return nil
+	}
 	if index := strings.LastIndex(name, "."); index != -1 {
 		name = name[index+1:]
 	}
+	name = strings.Trim(name, "`")
 
 	pos, ok := typeMapper.byName[name]
 	fuzzName := strings.ReplaceAll(strings.ToLower(name), "_", "")
@@ -475,7 +519,7 @@ func (m *mapper) appendField(recordType reflect.Type, name string, typeMapper *m
 				tag:      &Tag{Name: name},
 				isPseudo: true,
 			})
-		idx := len(m.fields)
+		idx := len(m.fields) - 1
 		m.byName[name] = idx
 		m.byName[fuzzName] = idx
 		return nil
@@ -489,7 +533,7 @@ func (m *mapper) appendField(recordType reflect.Type, name string, typeMapper *m
 				tag:    &Tag{Name: name},
 				isFunc: fun,
 			})
-		idx := len(m.fields)
+		idx := len(m.fields) - 1
 		m.byName[name] = idx
 		m.byName[fuzzName] = idx
 		return nil
@@ -498,6 +542,24 @@ func (m *mapper) appendField(recordType reflect.Type, name string, typeMapper *m
 	if !ok {
 		pos, ok = typeMapper.byName[fuzzName]
 	}
+	if !ok && isPseudoColumn(name) {
+		if metaField := typeMapper.metaField(name); metaField != nil {
+			pos, ok = metaField.index, true
+		} else {
+			m.fields = append(m.fields,
+				field{
+					Field: &xunsafe.Field{
+						Name: name,
+						Type: reflect.TypeOf(0),
+					},
+					tag:    &Tag{Name: name},
+					isMeta: true,
+				})
+			idx := len(m.fields) - 1
+			m.byName[name] = idx
+			return nil
+		}
+	}
 	if !ok {
 		return fmt.Errorf("unable to match column: %v in type: %s", name, recordType.Name())
 	}
@@ -508,14 +570,40 @@ func (m *mapper) appendField(recordType reflect.Type, name string, typeMapper *m
 	return nil
 }
 
+// metaField returns field tagged with record metadata matching supplied pseudo column
+func (m *mapper) metaField(pseudoColumn string) *field {
+	switch strings.ToLower(pseudoColumn) {
+	case generationColumn:
+		return m.generation
+	case ttlColumn:
+		return m.ttl
+	}
+	return nil
+}
+
 func newTypeBasedMapper(recordType reflect.Type) (*mapper, error) {
+	typeMapper, violations := mapRecordType(recordType)
+	if len(violations) > 0 {
+		return nil, violations[0]
+	}
+	return typeMapper, nil
+}
+
+// mapRecordType creates type based mapper, it returns every detected field definition violation
+func mapRecordType(recordType reflect.Type) (*mapper, []error) {
+	if recordType.Kind() != reflect.Struct {
+		return nil, []error{fmt.Errorf("unsupported record type %v, expected struct", recordType)}
+	}
+	var violations []error
 	typeMapper := &mapper{fields: make([]field, 0), byName: make(map[string]int)}
+	columns := make(map[string]string)
 	var idIndex *int
 	for i := 0; i < recordType.NumField(); i++ {
 		aField := recordType.Field(i)
 		tag, err := ParseTag(aField.Tag.Get("aerospike"))
 		if err != nil {
-			return nil, err
+			violations = append(violations, fmt.Errorf("invalid %v field tag: %w", aField.Name, err))
+			continue
 		}
 		if tag.Name == "" {
 			tag.Name = aField.Name
@@ -530,10 +618,27 @@ func newTypeBasedMapper(recordType reflect.Type) (*mapper, error) {
 				idIndex = &idx
 			}
 		}
+		if tag.IsEncrypted && (tag.IsPK || tag.IsMapKey || tag.IsArrayIndex || tag.IsSecondaryIndex) {
+			violations = append(violations, fmt.Errorf("unsupported encrypt tag on key or index field %v", aField.Name))
+		}
+		if tag.IsEncrypted && tag.Codec == "" && !isStringType(aField.Type) && !isBytesType(aField.Type) {
+			violations = append(violations, fmt.Errorf("unsupported encrypt tag on %v field %v, expected string or []byte", aField.Type, aField.Name))
+		}
+		if tag.IsDecimal && !isDecimalType(aField.Type) {
+			violations = append(violations, fmt.Errorf("unsupported scale tag on %v field %v", aField.Type, aField.Name))
+		}
+		if tag.Codec != "" && (tag.IsPK || tag.IsMapKey || tag.IsArrayIndex || tag.IsSecondaryIndex) {
+			violations = append(violations, fmt.Errorf("unsupported codec tag on key or index field %v", aField.Name))
+		}
+		if prev, ok := columns[tag.Name]; ok {
+			violations = append(violations, fmt.Errorf("duplicate column %v on fields %v and %v", tag.Name, prev, aField.Name))
+		}
+		columns[tag.Name] = aField.Name
 		mapperField := typeMapper.addField(aField, tag)
 		if tag.IsPK {
 			if typeMapper.pk != nil {
-				return nil, fmt.Errorf("multiple PK tags detected in %T", recordType)
+				violations = append(violations, fmt.Errorf("multiple PK tags detected in %v", recordType))
+				continue
 			}
 			typeMapper.pk = append(typeMapper.pk, mapperField)
 		}
@@ -545,7 +650,7 @@ func newTypeBasedMapper(recordType reflect.Type) (*mapper, error) {
 
 	typeMapper.columnZeroValues = make(map[string]interface{})
 
-	return typeMapper, nil
+	return typeMapper, violations
 }
 
 func baseType(rType reflect.Type) reflect.Type {
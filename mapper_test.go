@@ -0,0 +1,46 @@
+package aerospike
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlparser"
+	"reflect"
+	"testing"
+)
+
+func Test_newQueryMapper_appendField(t *testing.T) {
+	type Record struct {
+		Id   int    `aerospike:"id,pk=true"`
+		Name string `aerospike:"name"`
+	}
+	typeMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	aQuery, err := sqlparser.ParseQuery("SELECT id, 'x' AS tag, COUNT(*) AS cnt, name FROM x")
+	if !assert.Nil(t, err) {
+		return
+	}
+	aMapper, err := newQueryMapper(reflect.TypeOf(Record{}), aQuery, typeMapper)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		column       string
+		expectPseudo bool
+		expectFunc   bool
+	}{
+		{column: "id"},
+		{column: "tag", expectPseudo: true},
+		{column: "cnt", expectFunc: true},
+		{column: "name"},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		if !assert.NotNil(t, aField, testCase.column) {
+			continue
+		}
+		assert.Equal(t, testCase.column, aField.Column(), testCase.column)
+		assert.Equal(t, testCase.expectPseudo, aField.isPseudo, testCase.column)
+		assert.Equal(t, testCase.expectFunc, aField.isFunc, testCase.column)
+	}
+}
@@ -0,0 +1,375 @@
+package aerospike
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/viant/xunsafe"
+)
+
+var nestedMappers = struct {
+	mux     sync.RWMutex
+	mappers map[reflect.Type]*mapper
+}{mappers: map[reflect.Type]*mapper{}}
+
+// isNestedStruct returns true for struct (or pointer to struct) types stored as map bins
+func isNestedStruct(rType reflect.Type) bool {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType.Kind() == reflect.Struct && rType != timeType && lookupConverter(rType) == nil
+}
+
+// nestedMapper returns cached mapper of nested struct type
+func nestedMapper(rType reflect.Type) (*mapper, error) {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	nestedMappers.mux.RLock()
+	ret, ok := nestedMappers.mappers[rType]
+	nestedMappers.mux.RUnlock()
+	if ok {
+		return ret, nil
+	}
+	ret, err := newTypeBasedMapper(rType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nested type %v: %w", rType, err)
+	}
+	nestedMappers.mux.Lock()
+	nestedMappers.mappers[rType] = ret
+	nestedMappers.mux.Unlock()
+	return ret, nil
+}
+
+// encodeNested converts nested struct value to map bin value keyed by nested field column names
+func (f *field) encodeNested(value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Kind() != reflect.Struct {
+		return value, nil
+	}
+	aMapper, err := nestedMapper(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(aMapper.fields))
+	for i := range aMapper.fields {
+		nested := &aMapper.fields[i]
+		if nested.tag.Ignore || !v.Type().Field(int(nested.Field.Index)).IsExported() {
+			continue
+		}
+		fieldValue := v.Field(int(nested.Field.Index)).Interface()
+		if nested.tag.OmitEmpty && isEmptyValue(fieldValue) {
+			continue
+		}
+		item, err := nested.ensureValidValueType(fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v.%v value: %w", f.Column(), nested.Column(), err)
+		}
+		if item == nil {
+			continue
+		}
+		result[nested.Column()] = item
+	}
+	return result, nil
+}
+
+// decodeValue converts bin value to field type value
+func (f *field) decodeValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return reflect.Zero(f.Type).Interface(), nil
+	}
+	if f.tag.Codec != "" {
+		return f.decode(value)
+	}
+	if f.tag.IsDecimal {
+		return f.decodeDecimal(value)
+	}
+	if f.tag.hasTimeEncoding() && isTimeType(f.Type) {
+		ts, err := f.tag.decodeTime(value)
+		if err != nil {
+			return nil, err
+		}
+		return reflectValueOf(ts, f.Type).Interface(), nil
+	}
+	result, err := decodeTyped(value, f.Type)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %v: %w", f.Column(), err)
+	}
+	return result.Interface(), nil
+}
+
+// decodeTyped recursively converts bin value to target type value, including list and map elements
+func decodeTyped(value interface{}, target reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(target), nil
+	}
+	if target.Kind() == reflect.Ptr {
+		elem, err := decodeTyped(value, target.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(target.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+	srcType := reflect.TypeOf(value)
+	if srcType == target {
+		return reflect.ValueOf(value), nil
+	}
+	if conv := lookupConverter(target); conv != nil && conv.fromBin != nil {
+		converted, err := conv.convertFromBin(value, target)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(converted), nil
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case target == timeType:
+		ts, err := (&Tag{}).decodeTime(value)
+		return reflect.ValueOf(ts), err
+	case isNestedStruct(target):
+		return decodeNested(value, target)
+	case target.Kind() == reflect.Interface && srcType.AssignableTo(target):
+		result := reflect.New(target).Elem()
+		result.Set(v)
+		return result, nil
+	case target.Kind() == reflect.Slice && target.Elem().Kind() != reflect.Uint8 && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+		result := reflect.MakeSlice(target, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := decodeTyped(v.Index(i).Interface(), target.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid item %v: %w", i, err)
+			}
+			result.Index(i).Set(item)
+		}
+		return result, nil
+	case target.Kind() == reflect.Map && v.Kind() == reflect.Map:
+		result := reflect.MakeMapWithSize(target, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := decodeTyped(iter.Key().Interface(), target.Key())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid key %v: %w", iter.Key().Interface(), err)
+			}
+			item, err := decodeTyped(iter.Value().Interface(), target.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid %v value: %w", iter.Key().Interface(), err)
+			}
+			result.SetMapIndex(key, item)
+		}
+		return result, nil
+	case isNumericKind(srcType.Kind()) && isNumericKind(target.Kind()):
+		converted, err := coerceNumber(value, target)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(converted), nil
+	case srcType.AssignableTo(target):
+		result := reflect.New(target).Elem()
+		result.Set(v)
+		return result, nil
+	case srcType.ConvertibleTo(target) && (srcType.Kind() == target.Kind() || target.Kind() == reflect.String && srcType.Kind() == reflect.Slice):
+		return v.Convert(target), nil
+	}
+	return reflect.Value{}, fmt.Errorf("can't convert %T to %v", value, target)
+}
+
+// isCollectionType returns true for slice (other than []byte) and map types, or pointers to them
+func isCollectionType(rType reflect.Type) bool {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return (rType.Kind() == reflect.Slice && rType.Elem().Kind() != reflect.Uint8) || rType.Kind() == reflect.Map
+}
+
+// hasTypedElements returns true if collection type elements (or keys) need encoding to be stored in a bin
+func hasTypedElements(rType reflect.Type) bool {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	switch rType.Kind() {
+	case reflect.Slice:
+		if rType.Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+		return isTypedElement(rType.Elem())
+	case reflect.Map:
+		return isTypedElement(rType.Key()) || isTypedElement(rType.Elem())
+	}
+	return false
+}
+
+func isTypedElement(rType reflect.Type) bool {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return isNestedStruct(rType) || lookupConverter(rType) != nil || hasTypedElements(rType)
+}
+
+// encodeTyped recursively converts collection value to bin value, encoding nested structs and converter types
+func encodeTyped(value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if conv := lookupConverter(v.Type()); conv != nil && conv.toBin != nil {
+		return conv.convertToBin(v.Interface())
+	}
+	switch {
+	case isNestedStruct(v.Type()):
+		return (&field{Field: &xunsafe.Field{Name: v.Type().Name(), Type: v.Type()}, tag: &Tag{Name: v.Type().Name()}}).encodeNested(v.Interface())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		if v.IsNil() {
+			return nil, nil
+		}
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := encodeTyped(v.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("invalid item %v: %w", i, err)
+			}
+			result[i] = item
+		}
+		return result, nil
+	case v.Kind() == reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		result := make(map[interface{}]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := encodeTyped(iter.Key().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %v: %w", iter.Key().Interface(), err)
+			}
+			item, err := encodeTyped(iter.Value().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v value: %w", iter.Key().Interface(), err)
+			}
+			result[key] = item
+		}
+		return result, nil
+	}
+	return v.Interface(), nil
+}
+
+// decodeNested converts map bin value to nested struct value
+func decodeNested(value interface{}, rType reflect.Type) (reflect.Value, error) {
+	aMapper, err := nestedMapper(rType)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	result := reflect.New(rType).Elem()
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map {
+		return reflect.Value{}, fmt.Errorf("expected map but had %T", value)
+	}
+	for i := range aMapper.fields {
+		nested := &aMapper.fields[i]
+		if nested.tag.Ignore || !rType.Field(int(nested.Field.Index)).IsExported() {
+			continue
+		}
+		item := v.MapIndex(reflect.ValueOf(nested.Column()))
+		if !item.IsValid() {
+			continue
+		}
+		decoded, err := nested.decodeValue(item.Interface())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if decoded == nil {
+			continue
+		}
+		result.Field(int(nested.Field.Index)).Set(reflect.ValueOf(decoded))
+	}
+	return result, nil
+}
+
+// reflectValueOf returns value as target type value, wrapping it with pointer when target is a pointer type
+func reflectValueOf(value interface{}, target reflect.Type) reflect.Value {
+	v := reflect.ValueOf(value)
+	if target.Kind() == reflect.Ptr && v.Type() != target {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr
+	}
+	return v
+}
+
+// lookupPath returns fields of dotted nested column path, starting with the bin field, or nil if name is not a nested path
+func (m *mapper) lookupPath(name string) []*field {
+	parts := strings.Split(strings.Trim(name, "`"), ".")
+	for i := 0; i < len(parts)-1; i++ {
+		root := m.getField(parts[i])
+		if root == nil || !isNestedStruct(root.Type) {
+			continue
+		}
+		path := []*field{root}
+		for _, part := range parts[i+1:] {
+			parent := path[len(path)-1]
+			if !isNestedStruct(parent.Type) {
+				return nil
+			}
+			aMapper, err := nestedMapper(parent.Type)
+			if err != nil {
+				return nil
+			}
+			nested := aMapper.getField(part)
+			if nested == nil {
+				return nil
+			}
+			path = append(path, nested)
+		}
+		return path
+	}
+	return nil
+}
+
+// pathName returns dotted column path
+func pathName(path []*field) string {
+	names := make([]string, len(path))
+	for i, aField := range path {
+		names[i] = aField.Column()
+	}
+	return strings.Join(names, ".")
+}
+
+// pathValue returns typed value of nested column path from record bins
+func (f *field) pathValue(bins map[string]interface{}) (interface{}, error) {
+	value, ok := bins[f.path[0].Column()]
+	for _, nested := range f.path[1:] {
+		if !ok || value == nil {
+			return nil, nil
+		}
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Map {
+			return nil, fmt.Errorf("unable to read %v: expected map but had %T", pathName(f.path), value)
+		}
+		item := v.MapIndex(reflect.ValueOf(nested.Column()))
+		if ok = item.IsValid(); ok {
+			value = item.Interface()
+		}
+	}
+	if !ok || value == nil {
+		return nil, nil
+	}
+	return f.path[len(f.path)-1].decodeValue(value)
+}
@@ -0,0 +1,207 @@
+package aerospike
+
+import (
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlparser"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testGeo struct {
+	Lat float64 `aerospike:"lat"`
+	Lng float64 `aerospike:"lng"`
+}
+
+type testAddress struct {
+	City     string    `aerospike:"city"`
+	Zip      string    `aerospike:"zip"`
+	Since    time.Time `aerospike:"since,unixms"`
+	Geo      *testGeo  `aerospike:"geo"`
+	internal string
+}
+
+type testCustomer struct {
+	Id      int         `aerospike:"id,pk=true"`
+	Name    string      `aerospike:"name"`
+	Address testAddress `aerospike:"address"`
+}
+
+func Test_nestedStruct(t *testing.T) {
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(testCustomer{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	address := testAddress{City: "Austin", Zip: "73301", Since: time.UnixMilli(1700000000000).UTC(), Geo: &testGeo{Lat: 30.2, Lng: -97.7}, internal: "skipped"}
+	aField := aMapper.getField("address")
+	bin, err := aField.ensureValidValueType(address)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]interface{}{
+		"city":  "Austin",
+		"zip":   "73301",
+		"since": int64(1700000000000),
+		"geo":   map[string]interface{}{"lat": 30.2, "lng": -97.7},
+	}, bin)
+
+	stored := map[interface{}]interface{}{
+		"city":  "Austin",
+		"zip":   "73301",
+		"since": 1700000000000,
+		"geo":   map[interface{}]interface{}{"lat": 30.2, "lng": -97.7},
+	}
+	decoded, err := aField.decodeValue(stored)
+	if !assert.Nil(t, err) {
+		return
+	}
+	address.internal = ""
+	assert.Equal(t, address, decoded)
+}
+
+func Test_lookupPath(t *testing.T) {
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(testCustomer{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		name        string
+		expect      string
+	}{
+		{description: "nested column", name: "address.city", expect: "address.city"},
+		{description: "qualified nested column", name: "c.address.zip", expect: "address.zip"},
+		{description: "deep nested column", name: "address.geo.lat", expect: "address.geo.lat"},
+		{description: "qualified column", name: "c.name", expect: ""},
+		{description: "unknown nested column", name: "address.country", expect: ""},
+	}
+	for _, testCase := range testCases {
+		path := aMapper.lookupPath(testCase.name)
+		if testCase.expect == "" {
+			assert.Nil(t, path, testCase.description)
+			continue
+		}
+		assert.Equal(t, testCase.expect, pathName(path), testCase.description)
+	}
+
+	path := aMapper.lookupPath("address.geo.lat")
+	value, err := (&field{path: path}).pathValue(map[string]interface{}{
+		"address": map[interface{}]interface{}{"geo": map[interface{}]interface{}{"lat": 30.2}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 30.2, value)
+	assert.Len(t, pathContext(path), 1)
+
+	aSet := &set{mapOrder: MapOrderKey}
+	assert.Equal(t, []*as.CDTContext{as.CtxMapKeyCreate(as.StringValue("geo"), as.MapOrder.KEY_ORDERED)}, pathCreateContext(aSet, path))
+	op, err := pathPutOperation(aSet, path, 31)
+	assert.Nil(t, err)
+	assert.NotNil(t, op)
+}
+
+func Test_pathExpression(t *testing.T) {
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(testCustomer{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		name        string
+		operator    string
+		values      []interface{}
+		expectErr   bool
+	}{
+		{description: "equal", name: "address.zip", operator: "=", values: []interface{}{"73301"}},
+		{description: "in", name: "address.zip", operator: "in", values: []interface{}{"73301", "73344"}},
+		{description: "between", name: "address.geo.lat", operator: "between", values: []interface{}{30, 31.5}},
+		{description: "time", name: "address.since", operator: ">", values: []interface{}{time.Now()}},
+		{description: "invalid between", name: "address.geo.lat", operator: "between", values: []interface{}{30}, expectErr: true},
+		{description: "unsupported operator", name: "address.zip", operator: "like", values: []interface{}{"7%"}, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		expression, err := pathExpression(aMapper.lookupPath(testCase.name), testCase.operator, testCase.values)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.NotNil(t, expression, testCase.description)
+	}
+}
+
+func Test_newQueryMapperNestedColumn(t *testing.T) {
+	typeMapper, err := newTypeBasedMapper(reflect.TypeOf(testCustomer{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	aQuery, err := sqlparser.ParseQuery("SELECT id, address.city FROM customers WHERE pk = ?")
+	if !assert.Nil(t, err) {
+		return
+	}
+	aMapper, err := newQueryMapper(reflect.TypeOf(testCustomer{}), aQuery, typeMapper)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"id", "address"}, aMapper.expandBins())
+	assert.Equal(t, "address.city", aMapper.fields[1].tag.Name)
+}
+
+func Test_typedCollections(t *testing.T) {
+	type Record struct {
+		Id        int                    `aerospike:"id,pk=true"`
+		Tags      []string               `aerospike:"tags"`
+		Counts    []int64                `aerospike:"counts"`
+		Scores    map[string]float64     `aerospike:"scores"`
+		Addresses []testAddress          `aerospike:"addresses"`
+		ByCity    map[string]*testGeo    `aerospike:"byCity"`
+		Matrix    *[][]int               `aerospike:"matrix"`
+		Timeouts  []time.Duration        `aerospike:"timeouts"`
+		Any       map[string]interface{} `aerospike:"any"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	matrix := [][]int{{1, 2}, {3}}
+	var testCases = []struct {
+		description string
+		column      string
+		bin         interface{}
+		expect      interface{}
+		expectErr   bool
+	}{
+		{description: "strings", column: "tags", bin: []interface{}{"a", "b"}, expect: []string{"a", "b"}},
+		{description: "int64s", column: "counts", bin: []interface{}{1, 2}, expect: []int64{1, 2}},
+		{description: "string float map", column: "scores", bin: map[interface{}]interface{}{"a": 1.5, "b": 2}, expect: map[string]float64{"a": 1.5, "b": 2}},
+		{description: "structs", column: "addresses", bin: []interface{}{map[interface{}]interface{}{"city": "Austin"}}, expect: []testAddress{{City: "Austin"}}},
+		{description: "struct pointer map", column: "byCity", bin: map[interface{}]interface{}{"Austin": map[interface{}]interface{}{"lat": 30.2}}, expect: map[string]*testGeo{"Austin": {Lat: 30.2}}},
+		{description: "nested slices pointer", column: "matrix", bin: []interface{}{[]interface{}{1, 2}, []interface{}{3}}, expect: &matrix},
+		{description: "converter elements", column: "timeouts", bin: []interface{}{int64(time.Second)}, expect: []time.Duration{time.Second}},
+		{description: "interface map", column: "any", bin: map[interface{}]interface{}{"a": []interface{}{1}}, expect: map[string]interface{}{"a": []interface{}{1}}},
+		{description: "invalid element", column: "counts", bin: []interface{}{"x"}, expectErr: true},
+		{description: "overflow element", column: "counts", bin: []interface{}{uint64(1 << 63)}, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		actual, err := aField.decodeValue(testCase.bin)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+
+	bin, err := aMapper.getField("addresses").ensureValidValueType([]testAddress{{City: "Austin", Geo: &testGeo{Lat: 1}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"city": "Austin", "zip": "", "since": time.Time{}.UnixMilli(), "geo": map[string]interface{}{"lat": 1.0, "lng": 0.0}}}, bin)
+	bin, err = aMapper.getField("timeouts").ensureValidValueType([]time.Duration{time.Second})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int64(time.Second)}, bin)
+	bin, err = aMapper.getField("tags").ensureValidValueType([]string{"a"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a"}, bin, "plain collections are passed as is")
+}
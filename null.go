@@ -0,0 +1,73 @@
+package aerospike
+
+import (
+	"fmt"
+	"reflect"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+// isNullValue returns true for nil, nil pointer or nil interface pointer value, writing NULL deletes the bin
+func isNullValue(value interface{}) bool {
+	if iFacePtr, ok := value.(*interface{}); ok && iFacePtr != nil {
+		value = *iFacePtr
+	}
+	v := reflect.ValueOf(value)
+	return !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil())
+}
+
+// isEmptyValue returns true for NULL, zero or empty collection value, omitted on write by omitempty fields
+func isEmptyValue(value interface{}) bool {
+	if isNullValue(value) {
+		return true
+	}
+	if iFacePtr, ok := value.(*interface{}); ok {
+		value = *iFacePtr
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr:
+		return false
+	}
+	return v.IsZero()
+}
+
+// isNullable returns true if missing bin is read as NULL rather than field type zero value
+func (f *field) isNullable() bool {
+	if f.tag.IsPK || f.tag.IsGeneration || f.tag.IsTTL {
+		return false
+	}
+	if f.tag.IsNullable {
+		return true
+	}
+	switch f.Type.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return true
+	}
+	return reflect.PtrTo(f.Type).Implements(scannerType)
+}
+
+// nullExpression returns filter expression of IS NULL (or IS NOT NULL) criteria of a bin or nested column path
+func nullExpression(aField *field, path []*field, isNull bool) (*as.Expression, error) {
+	var exists *as.Expression
+	if path != nil {
+		leaf := path[len(path)-1]
+		count := as.ExpMapGetByKey(as.MapReturnType.COUNT, as.ExpTypeINT, as.ExpStringVal(leaf.Column()), as.ExpMapBin(path[0].Column()), pathContext(path)...)
+		exists = as.ExpAnd(as.ExpBinExists(path[0].Column()), as.ExpGreater(count, as.ExpIntVal(0)))
+	} else {
+		if aField == nil {
+			return nil, fmt.Errorf("unable to find null criteria column")
+		}
+		tag := aField.tag
+		if tag.IsPK || tag.IsMapKey || tag.IsArrayIndex || tag.IsSecondaryIndex || tag.IsGeneration || tag.IsTTL || aField.isPseudo || aField.isMeta {
+			return nil, fmt.Errorf("unsupported null criteria on key or pseudo column: %v", aField.Column())
+		}
+		exists = as.ExpBinExists(aField.Column())
+	}
+	if isNull {
+		return as.ExpNot(exists), nil
+	}
+	return exists, nil
+}
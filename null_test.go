@@ -0,0 +1,137 @@
+package aerospike
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlparser"
+	"github.com/viant/xunsafe"
+	"reflect"
+	"testing"
+)
+
+type testNullRecord struct {
+	Id      int            `aerospike:"id,pk=true"`
+	Name    string         `aerospike:"name,omitempty"`
+	Email   *string        `aerospike:"email"`
+	Nick    sql.NullString `aerospike:"nick"`
+	Score   sql.NullInt64  `aerospike:"score"`
+	Visits  int            `aerospike:"visits"`
+	Comment string         `aerospike:"comment,nullable"`
+	Address testAddress    `aerospike:"address"`
+}
+
+func Test_isEmptyValue(t *testing.T) {
+	var nilIface interface{}
+	zero := 0
+	var testCases = []struct {
+		description string
+		value       interface{}
+		expectNull  bool
+		expectEmpty bool
+	}{
+		{description: "nil", value: nil, expectNull: true, expectEmpty: true},
+		{description: "nil pointer", value: (*string)(nil), expectNull: true, expectEmpty: true},
+		{description: "nil interface pointer", value: &nilIface, expectNull: true, expectEmpty: true},
+		{description: "zero int", value: 0, expectEmpty: true},
+		{description: "empty string", value: "", expectEmpty: true},
+		{description: "empty slice", value: []string{}, expectEmpty: true},
+		{description: "invalid null string", value: sql.NullString{}, expectEmpty: true},
+		{description: "pointer to zero", value: &zero},
+		{description: "value", value: "x"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectNull, isNullValue(testCase.value), testCase.description)
+		assert.Equal(t, testCase.expectEmpty, isEmptyValue(testCase.value), testCase.description)
+	}
+}
+
+func Test_nullableRows(t *testing.T) {
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(testNullRecord{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	record := &testNullRecord{}
+	rows := &Rows{mapper: aMapper, record: record, recordType: reflect.TypeOf(testNullRecord{})}
+	var expectNullable = map[string]bool{"id": false, "name": false, "email": true, "nick": true, "score": true, "visits": false, "comment": true, "address": false}
+	for i, aField := range aMapper.fields {
+		nullable, ok := rows.ColumnTypeNullable(i)
+		assert.True(t, ok)
+		assert.Equal(t, expectNullable[aField.Column()], nullable, aField.Column())
+	}
+
+	dest := make([]driver.Value, len(aMapper.fields))
+	for i := range dest {
+		dest[i] = "stale"
+	}
+	err = rows.transferBinValues(dest, &as.Record{Bins: as.BinMap{"id": 1, "nick": "bob", "score": 7}}, xunsafe.AsPointer(record))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, []driver.Value{1, "", nil, "bob", int64(7), 0, nil, testAddress{}}, dest)
+	assert.Equal(t, sql.NullString{String: "bob", Valid: true}, record.Nick)
+	assert.Equal(t, sql.NullInt64{Int64: 7, Valid: true}, record.Score)
+
+	var nick sql.NullString
+	assert.Nil(t, nick.Scan(dest[2]))
+	assert.False(t, nick.Valid, "missing bin is scanned as invalid sql.NullString")
+}
+
+func Test_nullCriteria(t *testing.T) {
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(testNullRecord{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		SQL         string
+		expectErr   bool
+	}{
+		{description: "is null", SQL: "SELECT * FROM users WHERE email IS NULL"},
+		{description: "is not null with pk", SQL: "SELECT * FROM users WHERE pk = 1 AND nick IS NOT NULL"},
+		{description: "nested column is null", SQL: "SELECT * FROM users WHERE address.geo.lat IS NULL"},
+		{description: "pk is null", SQL: "SELECT * FROM users WHERE id IS NULL", expectErr: true},
+	}
+	for _, testCase := range testCases {
+		aQuery, err := sqlparser.ParseQuery(testCase.SQL)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		stmt := &Statement{mapper: aMapper}
+		err = stmt.updateCriteria(aQuery.Qualify, nil, true)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.NotNil(t, stmt.filterExpression, testCase.description)
+	}
+}
+
+func Test_nullTags(t *testing.T) {
+	var testCases = []struct {
+		description     string
+		tag             string
+		expectOmitEmpty bool
+		expectNullable  bool
+		expectErr       bool
+	}{
+		{description: "flags", tag: "name,omitempty,nullable", expectOmitEmpty: true, expectNullable: true},
+		{description: "explicit true", tag: "name,omitempty=true,nullable=true", expectOmitEmpty: true, expectNullable: true},
+		{description: "explicit false", tag: "name,omitempty=false,nullable=false"},
+		{description: "invalid omitempty", tag: "name,omitempty=x", expectErr: true},
+	}
+	for _, testCase := range testCases {
+		tag, err := ParseTag(testCase.tag)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectOmitEmpty, tag.OmitEmpty, testCase.description)
+		assert.Equal(t, testCase.expectNullable, tag.IsNullable, testCase.description)
+	}
+}
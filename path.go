@@ -0,0 +1,176 @@
+package aerospike
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+)
+
+// pathContext returns CDT context of the nested column path parent map
+func pathContext(path []*field) []*as.CDTContext {
+	var result []*as.CDTContext
+	for _, nested := range path[1 : len(path)-1] {
+		result = append(result, as.CtxMapKey(as.StringValue(nested.Column())))
+	}
+	return result
+}
+
+// pathCreateContext returns CDT context of the nested column path parent map, missing parent maps are created
+// with set map order
+func pathCreateContext(aSet *set, path []*field) []*as.CDTContext {
+	var result []*as.CDTContext
+	for _, nested := range path[1 : len(path)-1] {
+		result = append(result, aSet.mapKeyCreateContext(as.StringValue(nested.Column())))
+	}
+	return result
+}
+
+// pathPutOperation returns map put operation of nested column path value
+func pathPutOperation(aSet *set, path []*field, value interface{}) (*as.Operation, error) {
+	leaf := path[len(path)-1]
+	value, err := leaf.ensureValidValueType(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v value: %w", pathName(path), err)
+	}
+	if value == nil {
+		return as.MapRemoveByKeyOp(path[0].Column(), leaf.Column(), as.MapReturnType.NONE, pathContext(path)...), nil
+	}
+	return as.MapPutOp(aSet.mapWritePolicy(), path[0].Column(), leaf.Column(), value, pathCreateContext(aSet, path)...), nil
+}
+
+// pathExpression returns filter expression of nested column path criteria
+func pathExpression(path []*field, operator string, values []interface{}) (*as.Expression, error) {
+	leaf := path[len(path)-1]
+	var operands []*as.Expression
+	var expType as.ExpType
+	for _, value := range values {
+		operand, valueType, err := expressionValue(leaf, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v criteria value: %w", pathName(path), err)
+		}
+		operands = append(operands, operand)
+		expType = valueType
+	}
+	bin := as.ExpMapGetByKey(as.MapReturnType.VALUE, expType, as.ExpStringVal(leaf.Column()), as.ExpMapBin(path[0].Column()), pathContext(path)...)
+	expectValues := func(count int) error {
+		if len(operands) != count {
+			return fmt.Errorf("invalid %v criteria values", pathName(path))
+		}
+		return nil
+	}
+	switch strings.ToLower(operator) {
+	case "=", "!=", "<>", ">", ">=", "<", "<=":
+		if err := expectValues(1); err != nil {
+			return nil, err
+		}
+		switch operator {
+		case "=":
+			return as.ExpEq(bin, operands[0]), nil
+		case "!=", "<>":
+			return as.ExpNotEq(bin, operands[0]), nil
+		case ">":
+			return as.ExpGreater(bin, operands[0]), nil
+		case ">=":
+			return as.ExpGreaterEq(bin, operands[0]), nil
+		case "<":
+			return as.ExpLess(bin, operands[0]), nil
+		}
+		return as.ExpLessEq(bin, operands[0]), nil
+	case "between":
+		if err := expectValues(2); err != nil {
+			return nil, err
+		}
+		return as.ExpAnd(as.ExpGreaterEq(bin, operands[0]), as.ExpLessEq(bin, operands[1])), nil
+	case "in":
+		if len(operands) == 0 {
+			return nil, fmt.Errorf("invalid %v criteria values", pathName(path))
+		}
+		if len(operands) == 1 {
+			return as.ExpEq(bin, operands[0]), nil
+		}
+		var items []*as.Expression
+		for _, operand := range operands {
+			items = append(items, as.ExpEq(bin, operand))
+		}
+		return as.ExpOr(items...), nil
+	}
+	return nil, fmt.Errorf("unsupported operator of a nested column %v: %s", pathName(path), operator)
+}
+
+// expressionValue returns expression value and type of nested column criteria value
+func expressionValue(aField *field, value interface{}) (*as.Expression, as.ExpType, error) {
+	value, err := aField.ensureValidValueType(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch actual := value.(type) {
+	case string:
+		return as.ExpStringVal(actual), as.ExpTypeSTRING, nil
+	case bool:
+		return as.ExpBoolVal(actual), as.ExpTypeBOOL, nil
+	case []byte:
+		return as.ExpBlobVal(actual), as.ExpTypeBLOB, nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := coerceNumber(value, int64Type)
+		if err != nil {
+			return nil, 0, err
+		}
+		return as.ExpIntVal(i.(int64)), as.ExpTypeINT, nil
+	case reflect.Float32, reflect.Float64:
+		return as.ExpFloatVal(v.Float()), as.ExpTypeFLOAT, nil
+	case reflect.String:
+		return as.ExpStringVal(v.String()), as.ExpTypeSTRING, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported criteria value type: %T", value)
+}
+
+// IsFilteredOut returns true if operation was not performed because filter expression was false
+func IsFilteredOut(err error) bool {
+	return hasResultCode(err, types.FILTERED_OUT)
+}
+
+// readPolicy returns read policy with statement filter expression, or nil for default policy
+func (s *Statement) readPolicy() *as.BasePolicy {
+	if s.filterExpression == nil {
+		return nil
+	}
+	policy := *s.client.DefaultPolicy
+	policy.FilterExpression = s.filterExpression
+	return &policy
+}
+
+// batchPolicy returns batch policy with statement filter expression, or nil for default policy
+func (s *Statement) batchPolicy() *as.BatchPolicy {
+	if s.filterExpression == nil {
+		return nil
+	}
+	policy := *s.client.DefaultBatchPolicy
+	policy.FilterExpression = s.filterExpression
+	return &policy
+}
+
+// scanPolicy returns scan policy with statement filter expression, or nil for default policy
+func (s *Statement) scanPolicy() *as.ScanPolicy {
+	if s.filterExpression == nil {
+		return nil
+	}
+	policy := *s.client.DefaultScanPolicy
+	policy.FilterExpression = s.filterExpression
+	return &policy
+}
+
+// queryPolicy returns query policy with statement filter expression, or nil for default policy
+func (s *Statement) queryPolicy() *as.QueryPolicy {
+	if s.filterExpression == nil {
+		return nil
+	}
+	policy := *s.client.DefaultQueryPolicy
+	policy.FilterExpression = s.filterExpression
+	return &policy
+}
@@ -0,0 +1,197 @@
+package aerospike
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+type (
+	// MapOrder represents collection map bin order
+	MapOrder string
+	// MapWriteMode represents collection map bin entry write mode
+	MapWriteMode string
+	// ListOrder represents collection list bin order
+	ListOrder string
+)
+
+const (
+	MapOrderUnordered MapOrder = "UNORDERED"
+	MapOrderKey       MapOrder = "KEY"
+	MapOrderKeyValue  MapOrder = "KEY_VALUE"
+
+	MapWriteModeUpdate     MapWriteMode = "UPDATE"
+	MapWriteModeUpdateOnly MapWriteMode = "UPDATE_ONLY"
+	MapWriteModeCreateOnly MapWriteMode = "CREATE_ONLY"
+
+	ListOrderUnordered ListOrder = "UNORDERED"
+	ListOrderOrdered   ListOrder = "ORDERED"
+)
+
+// WithMapOrder sets order of created collection map bins
+func WithMapOrder(order MapOrder) Option {
+	return func(s *set) {
+		s.mapOrder = order
+	}
+}
+
+// WithKeyValueOrderedMap creates collection map bins as KEY_VALUE_ORDERED, making rank and value range reads efficient
+func WithKeyValueOrderedMap() Option {
+	return WithMapOrder(MapOrderKeyValue)
+}
+
+// WithMapWriteMode sets collection map entry write mode used by insert and update, upserts always create or update entries
+func WithMapWriteMode(mode MapWriteMode) Option {
+	return func(s *set) {
+		s.mapWriteMode = mode
+	}
+}
+
+// WithListOrder sets order of collection list bins
+func WithListOrder(order ListOrder) Option {
+	return func(s *set) {
+		s.listOrder = order
+	}
+}
+
+// WithUniqueList skips appending values already present in collection list bins
+func WithUniqueList() Option {
+	return func(s *set) {
+		s.listUnique = true
+	}
+}
+
+// WithBoundedList rejects list inserts beyond collection list bin boundaries
+func WithBoundedList() Option {
+	return func(s *set) {
+		s.listBounded = true
+	}
+}
+
+// mapPolicy returns collection map bin policy with supplied write flags
+func (s *set) mapPolicy(flags int) *as.MapPolicy {
+	switch s.mapOrder {
+	case MapOrderKey:
+		return as.NewMapPolicyWithFlags(as.MapOrder.KEY_ORDERED, flags)
+	case MapOrderKeyValue:
+		return as.NewMapPolicyWithFlags(as.MapOrder.KEY_VALUE_ORDERED, flags)
+	}
+	return as.NewMapPolicyWithFlags(as.MapOrder.UNORDERED, flags)
+}
+
+// mapKeyCreateContext returns map key CDT context creating missing map with set map order
+func (s *set) mapKeyCreateContext(key as.Value) *as.CDTContext {
+	switch s.mapOrder {
+	case MapOrderKey:
+		return as.CtxMapKeyCreate(key, as.MapOrder.KEY_ORDERED)
+	case MapOrderKeyValue:
+		return as.CtxMapKeyCreate(key, as.MapOrder.KEY_VALUE_ORDERED)
+	}
+	return as.CtxMapKeyCreate(key, as.MapOrder.UNORDERED)
+}
+
+// mapWritePolicy returns collection map bin policy with configured write mode
+func (s *set) mapWritePolicy() *as.MapPolicy {
+	switch s.mapWriteMode {
+	case MapWriteModeUpdateOnly:
+		return s.mapPolicy(as.MapWriteFlagsUpdateOnly)
+	case MapWriteModeCreateOnly:
+		return s.mapPolicy(as.MapWriteFlagsCreateOnly)
+	}
+	return s.mapPolicy(as.MapWriteFlagsDefault)
+}
+
+// mapUpdatePolicy returns collection map bin policy used by update, map bins created by update are KEY_ORDERED
+// unless set map order is configured
+func (s *set) mapUpdatePolicy() *as.MapPolicy {
+	if s.mapOrder != "" {
+		return s.mapWritePolicy()
+	}
+	return (&set{mapOrder: MapOrderKey, mapWriteMode: s.mapWriteMode}).mapWritePolicy()
+}
+
+// listPolicy returns collection list bin policy
+func (s *set) listPolicy() *as.ListPolicy {
+	order := as.ListOrderUnordered
+	if s.listOrder == ListOrderOrdered {
+		order = as.ListOrderOrdered
+	}
+	flags := as.ListWriteFlagsDefault
+	if s.listUnique {
+		flags |= as.ListWriteFlagsAddUnique | as.ListWriteFlagsNoFail
+	}
+	if s.listBounded {
+		flags |= as.ListWriteFlagsInsertBounded
+	}
+	return as.NewListPolicy(order, flags)
+}
+
+var (
+	registerAsExpr        = regexp.MustCompile(`(?i)\sAS\s`)
+	collectionOptionsExpr = regexp.MustCompile(`(?i)\s+WITH\s+(MAP\s+ORDER|MAP\s+WRITE\s+MODE|MAP\s+SHARDS|LIST\s+ORDER)\s+(\w+)|\s+WITH\s+LIST\s+(UNIQUE|BOUNDED)\b|\s+WITH\s+(BIN\s+ALIASING)\b`)
+)
+
+// extractCollectionOptions removes REGISTER SET collection policy clauses i.e. WITH MAP ORDER KEY_VALUE, WITH MAP SHARDS 8 or WITH BIN ALIASING and returns matching set options
+func extractCollectionOptions(SQL string) (string, []Option, error) {
+	header, spec := SQL, ""
+	if loc := registerAsExpr.FindStringIndex(SQL); loc != nil {
+		header, spec = SQL[:loc[0]], SQL[loc[0]:]
+	}
+	var options []Option
+	var err error
+	header = collectionOptionsExpr.ReplaceAllStringFunc(header, func(clause string) string {
+		match := collectionOptionsExpr.FindStringSubmatch(clause)
+		kind := strings.Join(strings.Fields(strings.ToUpper(match[1])), " ")
+		if match[3] != "" {
+			kind = "LIST " + strings.ToUpper(match[3])
+		}
+		if match[4] != "" {
+			kind = "BIN ALIASING"
+		}
+		option, e := newCollectionOption(kind, strings.ToUpper(match[2]))
+		if e != nil {
+			err = e
+		}
+		options = append(options, option)
+		return ""
+	})
+	return header + spec, options, err
+}
+
+func newCollectionOption(kind, value string) (Option, error) {
+	switch kind {
+	case "MAP ORDER":
+		switch value {
+		case "UNORDERED":
+			return WithMapOrder(MapOrderUnordered), nil
+		case "KEY", "KEY_ORDERED":
+			return WithMapOrder(MapOrderKey), nil
+		case "KEY_VALUE", "KEY_VALUE_ORDERED":
+			return WithMapOrder(MapOrderKeyValue), nil
+		}
+	case "MAP WRITE MODE":
+		switch mode := MapWriteMode(value); mode {
+		case MapWriteModeUpdate, MapWriteModeUpdateOnly, MapWriteModeCreateOnly:
+			return WithMapWriteMode(mode), nil
+		}
+	case "MAP SHARDS":
+		if shards, err := strconv.Atoi(value); err == nil && shards > 0 {
+			return WithMapShards(shards), nil
+		}
+	case "LIST ORDER":
+		switch order := ListOrder(value); order {
+		case ListOrderUnordered, ListOrderOrdered:
+			return WithListOrder(order), nil
+		}
+	case "LIST UNIQUE":
+		return WithUniqueList(), nil
+	case "LIST BOUNDED":
+		return WithBoundedList(), nil
+	case "BIN ALIASING":
+		return WithBinAliasing(), nil
+	}
+	return nil, fmt.Errorf("unsupported register set option: WITH %v", strings.TrimSpace(kind+" "+value))
+}
@@ -0,0 +1,101 @@
+package aerospike
+
+import (
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_extractCollectionOptions(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expectSQL   string
+		expectSet   *set
+		expectErr   bool
+	}{
+		{
+			description: "no options",
+			SQL:         "REGISTER SET Leaderboard/scores AS ?",
+			expectSQL:   "REGISTER SET Leaderboard/scores AS ?",
+		},
+		{
+			description: "map order",
+			SQL:         "REGISTER SET WITH MAP ORDER KEY_VALUE Leaderboard/scores AS ?",
+			expectSQL:   "REGISTER SET Leaderboard/scores AS ?",
+			expectSet:   &set{mapOrder: MapOrderKeyValue},
+		},
+		{
+			description: "ttl with map write mode",
+			SQL:         "REGISTER SET WITH TTL 10 with map write mode create_only Leaderboard/scores AS ?",
+			expectSQL:   "REGISTER SET WITH TTL 10 Leaderboard/scores AS ?",
+			expectSet:   &set{mapWriteMode: MapWriteModeCreateOnly},
+		},
+		{
+			description: "list options",
+			SQL:         "REGISTER SET WITH LIST ORDER ORDERED WITH LIST UNIQUE WITH LIST BOUNDED Tagged/tags AS ?",
+			expectSQL:   "REGISTER SET Tagged/tags AS ?",
+			expectSet:   &set{listOrder: ListOrderOrdered, listUnique: true, listBounded: true},
+		},
+		{
+			description: "spec is not altered",
+			SQL:         "REGISTER SET Tagged AS struct{Id int; Note string `aerospike:\"note\" comment:\" WITH LIST UNIQUE\"`}",
+			expectSQL:   "REGISTER SET Tagged AS struct{Id int; Note string `aerospike:\"note\" comment:\" WITH LIST UNIQUE\"`}",
+		},
+		{
+			description: "bin aliasing",
+			SQL:         "REGISTER SET WITH BIN ALIASING Accounts AS ?",
+			expectSQL:   "REGISTER SET Accounts AS ?",
+			expectSet:   &set{binAliasing: true},
+		},
+		{
+			description: "map shards",
+			SQL:         "REGISTER SET WITH MAP SHARDS 8 Leaderboard/scores AS ?",
+			expectSQL:   "REGISTER SET Leaderboard/scores AS ?",
+			expectSet:   &set{mapShards: 8},
+		},
+		{
+			description: "invalid map shards",
+			SQL:         "REGISTER SET WITH MAP SHARDS x Leaderboard/scores AS ?",
+			expectErr:   true,
+		},
+		{
+			description: "unsupported option value",
+			SQL:         "REGISTER SET WITH MAP ORDER VALUE Leaderboard/scores AS ?",
+			expectErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		SQL, options, err := extractCollectionOptions(testCase.SQL)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expectSQL, SQL, testCase.description)
+		aSet := &set{}
+		for _, option := range options {
+			option(aSet)
+		}
+		expectSet := testCase.expectSet
+		if expectSet == nil {
+			expectSet = &set{}
+		}
+		assert.EqualValues(t, expectSet, aSet, testCase.description)
+	}
+}
+
+func Test_setPolicies(t *testing.T) {
+	aSet := &set{mapOrder: MapOrderKeyValue, mapWriteMode: MapWriteModeUpdateOnly, listOrder: ListOrderOrdered, listUnique: true}
+	mapPolicy := aSet.mapWritePolicy()
+	assert.EqualValues(t, as.NewMapPolicyWithFlags(as.MapOrder.KEY_VALUE_ORDERED, as.MapWriteFlagsUpdateOnly), mapPolicy)
+	listPolicy := aSet.listPolicy()
+	assert.EqualValues(t, as.NewListPolicy(as.ListOrderOrdered, as.ListWriteFlagsAddUnique|as.ListWriteFlagsNoFail), listPolicy)
+	assert.EqualValues(t, as.NewMapPolicyWithFlags(as.MapOrder.UNORDERED, as.MapWriteFlagsDefault), (&set{}).mapWritePolicy())
+	assert.EqualValues(t, as.NewMapPolicy(as.MapOrder.KEY_ORDERED, as.MapWriteMode.UPDATE), (&set{}).mapUpdatePolicy(), "update default")
+	assert.EqualValues(t, mapPolicy, aSet.mapUpdatePolicy(), "configured update order")
+	assert.EqualValues(t, as.NewMapPolicyWithFlags(as.MapOrder.UNORDERED, as.MapWriteFlagsDefault), (&set{mapOrder: MapOrderUnordered}).mapUpdatePolicy())
+}
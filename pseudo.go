@@ -0,0 +1,157 @@
+package aerospike
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+const (
+	// generationColumn represents read-only record generation pseudo column
+	generationColumn = "_generation"
+	// ttlColumn represents record remaining time to live pseudo column
+	ttlColumn = "_ttl"
+
+	// ttlNeverExpire represents ttl value of a record that never expires
+	ttlNeverExpire = -1
+	// ttlDontUpdate represents ttl value that keeps record expiration unchanged on write
+	ttlDontUpdate = -2
+)
+
+var pseudoColumnNames = []string{generationColumn, ttlColumn}
+
+// isPseudoColumn returns true if name represents a record metadata pseudo column
+func isPseudoColumn(name string) bool {
+	name = strings.ToLower(strings.Trim(name, "`"))
+	for _, candidate := range pseudoColumnNames {
+		if name == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// quotePseudoColumns wraps pseudo column identifiers with backticks, sqlparser does not accept identifiers starting with underscore
+func quotePseudoColumns(SQL string) string {
+	lower := strings.ToLower(SQL)
+	if !strings.Contains(lower, generationColumn) && !strings.Contains(lower, ttlColumn) {
+		return SQL
+	}
+	builder := strings.Builder{}
+	inQuote := false
+	for i := 0; i < len(SQL); i++ {
+		c := SQL[i]
+		switch c {
+		case '\'':
+			inQuote = !inQuote
+		case '_':
+			if inQuote || (i > 0 && isIdentifierByte(SQL[i-1])) {
+				break
+			}
+			if name := matchPseudoColumn(lower[i:]); name != "" {
+				builder.WriteByte('`')
+				builder.WriteString(SQL[i : i+len(name)])
+				builder.WriteByte('`')
+				i += len(name) - 1
+				continue
+			}
+		}
+		builder.WriteByte(c)
+	}
+	return builder.String()
+}
+
+func matchPseudoColumn(fragment string) string {
+	for _, name := range pseudoColumnNames {
+		if !strings.HasPrefix(fragment, name) {
+			continue
+		}
+		if len(fragment) > len(name) && (isIdentifierByte(fragment[len(name)]) || fragment[len(name)] == '`') {
+			continue
+		}
+		return name
+	}
+	return ""
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '`' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// pseudoColumnValue returns record metadata value for supplied pseudo column
+func pseudoColumnValue(record *as.Record, name string) interface{} {
+	switch strings.ToLower(name) {
+	case generationColumn:
+		return int(record.Generation)
+	case ttlColumn:
+		switch record.Expiration {
+		case as.TTLDontExpire:
+			return ttlNeverExpire
+		}
+		return int(record.Expiration)
+	}
+	return nil
+}
+
+// asExpiration converts ttl column value to write policy expiration, zero value keeps set default expiration
+func asExpiration(value interface{}) (uint32, bool, error) {
+	value, err := extractKeyValue(value)
+	if err != nil || value == nil {
+		return 0, false, err
+	}
+	if duration, ok := value.(time.Duration); ok {
+		value = int64(duration / time.Second)
+	}
+	v := reflect.ValueOf(value)
+	var ttl int64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ttl = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() == as.TTLDontExpire {
+			return as.TTLDontExpire, true, nil
+		}
+		if v.Uint() > uint64(as.TTLDontUpdate) {
+			return 0, false, fmt.Errorf("invalid ttl value: %v", value)
+		}
+		ttl = int64(v.Uint())
+	default:
+		return 0, false, fmt.Errorf("unsupported ttl value type: %T", value)
+	}
+	switch {
+	case ttl == 0:
+		return 0, false, nil
+	case ttl == ttlNeverExpire:
+		return as.TTLDontExpire, true, nil
+	case ttl == ttlDontUpdate:
+		return as.TTLDontUpdate, true, nil
+	case ttl < 0 || ttl >= as.TTLDontUpdate:
+		return 0, false, fmt.Errorf("invalid ttl value: %v", value)
+	}
+	return uint32(ttl), true, nil
+}
+
+// asGeneration converts criteria or column value to record generation
+func asGeneration(value interface{}) (uint32, error) {
+	value, err := extractKeyValue(value)
+	if err != nil {
+		return 0, err
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 || v.Int() > int64(^uint32(0)) {
+			return 0, fmt.Errorf("invalid generation value: %v", value)
+		}
+		return uint32(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > uint64(^uint32(0)) {
+			return 0, fmt.Errorf("invalid generation value: %v", value)
+		}
+		return uint32(v.Uint()), nil
+	}
+	return 0, fmt.Errorf("unsupported generation value type: %T", value)
+}
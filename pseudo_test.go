@@ -0,0 +1,71 @@
+package aerospike
+
+import (
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_quotePseudoColumns(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      string
+	}{
+		{
+			description: "no pseudo columns",
+			SQL:         "SELECT id, name FROM users WHERE pk = ?",
+			expect:      "SELECT id, name FROM users WHERE pk = ?",
+		},
+		{
+			description: "select pseudo columns",
+			SQL:         "SELECT id, _generation, _TTL FROM users WHERE pk = ?",
+			expect:      "SELECT id, `_generation`, `_TTL` FROM users WHERE pk = ?",
+		},
+		{
+			description: "update generation criteria",
+			SQL:         "UPDATE users SET name = ? WHERE pk = ? AND _generation = ?",
+			expect:      "UPDATE users SET name = ? WHERE pk = ? AND `_generation` = ?",
+		},
+		{
+			description: "already quoted, literal and identifier suffix",
+			SQL:         "SELECT `_ttl`, user_ttl, _ttlx FROM users WHERE name = '_generation'",
+			expect:      "SELECT `_ttl`, user_ttl, _ttlx FROM users WHERE name = '_generation'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, quotePseudoColumns(testCase.SQL), testCase.description)
+	}
+}
+
+func Test_asExpiration(t *testing.T) {
+	var testCases = []struct {
+		description string
+		value       interface{}
+		expect      uint32
+		expectOk    bool
+		expectErr   bool
+	}{
+		{description: "seconds", value: 3600, expect: 3600, expectOk: true},
+		{description: "duration", value: 2 * time.Minute, expect: 120, expectOk: true},
+		{description: "never expire", value: -1, expect: as.TTLDontExpire, expectOk: true},
+		{description: "do not update", value: int64(-2), expect: as.TTLDontUpdate, expectOk: true},
+		{description: "set default", value: 0, expectOk: false},
+		{description: "nil pointer", value: (*int)(nil), expectOk: false},
+		{description: "invalid negative", value: -3, expectErr: true},
+		{description: "invalid type", value: "10", expectErr: true},
+	}
+
+	for _, testCase := range testCases {
+		actual, ok, err := asExpiration(testCase.value)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expectOk, ok, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -20,7 +20,7 @@ import (
 
 func (s *Statement) prepareSelect(SQL string) error {
 	var err error
-	if s.query, err = sqlparser.ParseQuery(SQL); err != nil {
+	if s.query, err = sqlparser.ParseQuery(quotePseudoColumns(SQL)); err != nil {
 		return err
 	}
 
@@ -136,21 +136,17 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 
 	aMapper := aSet.lookupQueryMapper(s.SQL)
 	if aMapper == nil {
-		aMapper, err = newQueryMapper(s.recordType, s.query, aSet.typeBasedMapper)
+		typeMapper, err := aSet.lookupTypeBasedMapper(s.recordType)
+		if err != nil {
+			return nil, err
+		}
+		aMapper, err = newQueryMapper(s.recordType, s.query, typeMapper)
 		if err != nil {
 			return nil, err
 		}
 		aSet.registerQueryMapper(s.SQL, aMapper)
 	}
-	row := reflect.New(s.recordType).Interface()
-	rows := &Rows{
-		zeroRecord: unsafe.Slice((*byte)(xunsafe.AsPointer(row)), s.recordType.Size()),
-		record:     row,
-		recordType: s.recordType,
-		mapper:     aMapper,
-		query:      s.query,
-		ctx:        ctx,
-	}
+	rows := s.newRows(ctx, aMapper)
 
 	if s.query.Qualify != nil {
 		s.query.Qualify.X = unwrapQualify(s.query.Qualify.X)
@@ -159,6 +155,9 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 	if err := s.updateCriteria(s.query.Qualify, args, true); err != nil {
 		return nil, err
 	}
+	if s.filterExpression != nil && s.collectionType != "" {
+		return nil, fmt.Errorf("unsupported nested column or null criteria with %v collection bin", s.collectionBin)
+	}
 
 	if s.falsePredicate {
 		rows.rowsReader = newRowsReader([]*as.Record{})
@@ -186,7 +185,7 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 			}
 		}
 		// Execute the query
-		recordset, err := s.queryWithCtx(ctx, nil, stmt)
+		recordset, err := s.queryWithCtx(ctx, s.queryPolicy(), stmt)
 		if err != nil {
 			return nil, err
 		}
@@ -203,13 +202,17 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 		return s.handleInformationSchema(ctx, keys, rows)
 	}
 
+	if len(aMapper.binFunctions) > 0 {
+		return s.handleBinFunctions(ctx, keys, rows)
+	}
+
 	switch len(keys) {
 	case 0:
-		if s.query.Qualify != nil {
+		if s.query.Qualify != nil && s.filterExpression == nil {
 			return nil, fmt.Errorf("executeselect: unsupported parameterizedQuery without mapKey")
 			//use parameterizedQuery call
 		} else {
-			recordset, err := s.scanAllWithCtx(ctx, nil, s.namespace, s.set, nil)
+			recordset, err := s.scanAllWithCtx(ctx, s.scanPolicy(), s.namespace, s.set, nil)
 			if err != nil {
 				return nil, fmt.Errorf("executeselect: unable to scan set %s due to %w", s.set, err)
 			}
@@ -224,6 +227,9 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 					if res.Record == nil {
 						continue
 					}
+					if err := s.restoreShardKey(aSet, res.Record); err != nil {
+						return nil, err
+					}
 					if err := s.handleMapBinResult(res.Record, &recs); err != nil {
 						return nil, err
 					}
@@ -257,9 +263,9 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 
 		var record *as.Record
 		if s.query.List.IsStarExpr() {
-			record, err = s.getWithCtx(ctx, nil, keys[0], nil)
+			record, err = s.getWithCtx(ctx, s.readPolicy(), keys[0], nil)
 		} else {
-			record, err = s.getWithCtx(ctx, nil, keys[0], bins)
+			record, err = s.getWithCtx(ctx, s.readPolicy(), keys[0], bins)
 		}
 		if err != nil {
 			return handleNotFoundError(err, rows)
@@ -275,7 +281,13 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 			return rows, nil
 		}
 
-		records, err := s.batchGetWithCtx(ctx, nil, keys, nil)
+		var records []*as.Record
+		var err error
+		if s.collectionType.IsMap() && aSet.isSharded() {
+			records, err = s.getMaps(ctx, aSet, keys)
+		} else {
+			records, err = s.batchGetWithCtx(ctx, s.batchPolicy(), keys, nil)
+		}
 		recs := make([]*as.Record, 0)
 		if s.collectionType.IsMap() {
 			for i := range records {
@@ -310,7 +322,7 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 		}
 		rows.rowsReader = newRowsReader(recs)
 		if err != nil {
-			if IsKeyNotFound(err) {
+			if IsKeyNotFound(err) || IsFilteredOut(err) {
 				return rows, nil
 			}
 			return nil, err
@@ -319,6 +331,28 @@ func (s *Statement) executeSelect(ctx context.Context, args []driver.NamedValue)
 	return rows, nil
 }
 
+func (s *Statement) newRows(ctx context.Context, aMapper *mapper) *Rows {
+	row := reflect.New(s.recordType).Interface()
+	rows := &Rows{
+		zeroRecord: unsafe.Slice((*byte)(xunsafe.AsPointer(row)), s.recordType.Size()),
+		record:     row,
+		recordType: s.recordType,
+		mapper:     aMapper,
+		query:      s.query,
+		ctx:        ctx,
+		set:        s.set,
+	}
+	if aSet, err := s.lookupSet(); err == nil {
+		rows.keyProvider = aSet.encryptionKeyProvider()
+	}
+	if fields := aMapper.chunkedFields(); len(fields) > 0 {
+		rows.loadChunks = func(ctx context.Context, record *as.Record) error {
+			return s.loadChunks(ctx, record, fields)
+		}
+	}
+	return rows
+}
+
 func unwrapQualify(n node.Node) node.Node {
 	if b, ok := n.(*expr.Binary); ok {
 		if b.Y == nil {
@@ -377,6 +411,8 @@ func (s *Statement) handleGroupBy(ctx context.Context, rows *Rows, keys []*as.Ke
 	return nil
 }
 
+// getAggregateOperation returns collection bin aggregate operation column, only COUNT is supported, so scale tags
+// do not apply to aggregates
 func (s *Statement) getAggregateOperation(rows *Rows, operations *[]*as.Operation) (string, error) {
 	funcColumn := ""
 	for col, call := range rows.mapper.aggregateColumn {
@@ -413,7 +449,13 @@ func (s *Statement) handleMapQuery(ctx context.Context, keys []*as.Key, rows *Ro
 		return s.handleMapListQuery(ctx, keys, rows)
 	}
 
-	var err error
+	ranking, err := s.valueRanking()
+	if err != nil {
+		return nil, err
+	}
+	if ranking != nil || s.valueRange != nil {
+		return s.handleMapValueQuery(ctx, keys, rows, ranking)
+	}
 	var op []*as.Operation
 
 	aSet, err := s.lookupSet()
@@ -455,7 +497,7 @@ func (s *Statement) handleMapQuery(ctx context.Context, keys []*as.Key, rows *Ro
 			}
 			op = append(op, as.MapGetByKeyListOp(s.collectionBin, keys, as.MapReturnType.KEY_VALUE))
 		}
-		result, err := s.operateWithCtx(ctx, writePolicy, keys[0], op)
+		result, err := s.operateMap(ctx, aSet, writePolicy, keys[0], op)
 		if err != nil {
 			return handleNotFoundError(err, rows)
 		}
@@ -476,7 +518,7 @@ func (s *Statement) handleMapQuery(ctx context.Context, keys []*as.Key, rows *Ro
 		rows.rowsReader = newRowsReader(recs)
 		return rows, nil
 	}
-	record, err := s.getWithCtx(ctx, nil, keys[0], []string{s.mapper.pk[0].Column(), s.collectionBin})
+	record, err := s.getMap(ctx, aSet, keys[0], []string{s.mapper.pk[0].Column(), s.collectionBin})
 	if err != nil {
 		return handleNotFoundError(err, rows)
 	}
@@ -510,7 +552,7 @@ func (s *Statement) handleMapListQuery(ctx context.Context, keys []*as.Key, rows
 		case len(s.mapKeyValues) > 1:
 			op = append(op, as.MapGetByKeyListOp(s.collectionBin, s.mapKeyValues, as.MapReturnType.KEY_VALUE))
 		}
-		result, err := s.operateWithCtx(ctx, writePolicy, keys[0], op)
+		result, err := s.operateMap(ctx, aSet, writePolicy, keys[0], op)
 		if err != nil {
 			return handleNotFoundError(err, rows)
 		}
@@ -531,7 +573,7 @@ func (s *Statement) handleMapListQuery(ctx context.Context, keys []*as.Key, rows
 		rows.rowsReader = newRowsReader(recs)
 		return rows, nil
 	}
-	record, err := s.getWithCtx(ctx, nil, keys[0], []string{s.mapper.pk[0].Column(), s.collectionBin})
+	record, err := s.getMap(ctx, aSet, keys[0], []string{s.mapper.pk[0].Column(), s.collectionBin})
 	if err != nil {
 		return handleNotFoundError(err, rows)
 	}
@@ -655,7 +697,7 @@ func (s *Statement) convertSliceOfInterfacesToRecords(values []interface{}) ([]*
 }
 
 func handleNotFoundError(err error, rows *Rows) (driver.Rows, error) {
-	if IsKeyNotFound(err) {
+	if IsKeyNotFound(err) || IsFilteredOut(err) {
 		rows.rowsReader = newRowsReader([]*as.Record{})
 		return rows, nil
 	}
@@ -674,6 +716,16 @@ func (s *Statement) handleListQuery(ctx context.Context, keys []*as.Key, rows *R
 	}
 	writePolicy := s.writePolicy(aSet, false)
 
+	if len(rows.mapper.aggregateColumn) == 0 {
+		ranking, err := s.valueRanking()
+		if err != nil {
+			return nil, err
+		}
+		if ranking != nil || s.valueRange != nil {
+			return s.handleListValueQuery(ctx, keys, rows, ranking)
+		}
+	}
+
 	if len(rows.mapper.aggregateColumn) > 0 { //for only one  aggregation func
 		if aggColumn, err = s.getAggregateOperation(rows, &operations); err != nil {
 			return nil, err
@@ -0,0 +1,271 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/viant/sqlparser"
+)
+
+// valueColumn represents value column name of collection entries without payload field
+const valueColumn = "value"
+
+type (
+	// valueRange represents collection entry value range criteria, both ends are inclusive
+	valueRange struct {
+		begin interface{}
+		end   interface{}
+	}
+
+	// valueRanking represents collection entry value ordering pushed down to rank operations
+	valueRanking struct {
+		desc  bool
+		limit int
+	}
+)
+
+// isValueColumn returns true if column is the value column of a collection entry
+func (s *Statement) isValueColumn(name string) bool {
+	if !s.collectionType.IsMap() && !s.collectionType.IsArray() {
+		return false
+	}
+	column := s.entryValueColumn()
+	if column == "" {
+		return false
+	}
+	name = strings.ToLower(strings.Trim(name, "`"))
+	if index := strings.LastIndex(name, "."); index != -1 {
+		name = name[index+1:]
+	}
+	return name == strings.ToLower(column)
+}
+
+// entryValueColumn returns collection entry value column, entries with a single payload field are stored as scalar
+// values ranked by the server, entries with multiple payload fields are objects without value column
+func (s *Statement) entryValueColumn() string {
+	if s.mapper == nil {
+		return valueColumn
+	}
+	var columns []string
+	for i := range s.mapper.fields {
+		aField := &s.mapper.fields[i]
+		if !aField.isBinField() || aField.tag.IsPK || aField.tag.IsMapKey || aField.tag.IsArrayIndex || aField.tag.IsSecondaryIndex || aField.tag.IsComponent {
+			continue
+		}
+		columns = append(columns, aField.Column())
+	}
+	switch len(columns) {
+	case 0:
+		return valueColumn
+	case 1:
+		return columns[0]
+	}
+	return ""
+}
+
+// valueRanking returns ORDER BY value [DESC] [LIMIT n] ranking or nil if query is not ordered by collection value
+func (s *Statement) valueRanking() (*valueRanking, error) {
+	if s.query == nil || len(s.query.OrderBy) == 0 {
+		return nil, nil
+	}
+	if len(s.query.OrderBy) > 1 || !s.isValueColumn(sqlparser.Stringify(s.query.OrderBy[0].Expr)) {
+		return nil, nil
+	}
+	ret := &valueRanking{desc: strings.EqualFold(s.query.OrderBy[0].Direction, "desc"), limit: -1}
+	if s.query.Limit != nil {
+		limit, err := strconv.ParseFloat(s.query.Limit.Value, 64)
+		if err != nil || limit < 0 || limit != math.Trunc(limit) {
+			return nil, fmt.Errorf("invalid limit: %v", s.query.Limit.Value)
+		}
+		ret.limit = int(limit)
+	}
+	return ret, nil
+}
+
+// handleMapValueQuery handles map bin rank or value range query with a single operate call
+func (s *Statement) handleMapValueQuery(ctx context.Context, keys []*as.Key, rows *Rows, ranking *valueRanking) (driver.Rows, error) {
+	if s.mapRangeFilter != nil || len(s.mapKeyValues) > 0 {
+		return nil, fmt.Errorf("unsupported criteria combination: mapKey and map value criteria")
+	}
+	var op *as.Operation
+	switch {
+	case s.valueRange != nil:
+		op = as.MapGetByValueRangeOp(s.collectionBin, s.valueRange.begin, inclusiveRangeEnd(s.valueRange.end), as.MapReturnType.KEY_VALUE)
+	case ranking.limit >= 0:
+		op = as.MapGetByRankRangeCountOp(s.collectionBin, ranking.rank(), ranking.limit, as.MapReturnType.KEY_VALUE)
+	default:
+		op = as.MapGetByRankRangeOp(s.collectionBin, 0, as.MapReturnType.KEY_VALUE)
+	}
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.operateMap(ctx, aSet, s.writePolicy(aSet, false), keys[0], []*as.Operation{op})
+	if err != nil {
+		return handleNotFoundError(err, rows)
+	}
+	pairs, _ := result.Bins[s.collectionBin].([]as.MapPair)
+	if ranking != nil {
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return ranking.less(pairs[i].Value, pairs[j].Value)
+		})
+		pairs = pairs[:ranking.count(len(pairs))]
+	}
+	records, err := s.convertMapPairsToRecords(keys, pairs)
+	if err != nil {
+		return nil, err
+	}
+	rows.rowsReader = newRowsReader(records)
+	return rows, nil
+}
+
+// handleListValueQuery handles list bin rank or value range query with a single operate call
+func (s *Statement) handleListValueQuery(ctx context.Context, keys []*as.Key, rows *Rows, ranking *valueRanking) (driver.Rows, error) {
+	if s.arrayRangeFilter != nil || len(s.arrayIndexValues) > 0 {
+		return nil, fmt.Errorf("unsupported criteria combination: list index and list value criteria")
+	}
+	var op *as.Operation
+	switch {
+	case s.valueRange != nil:
+		op = as.ListGetByValueRangeOp(s.collectionBin, s.valueRange.begin, inclusiveRangeEnd(s.valueRange.end), as.ListReturnTypeValue)
+	case ranking.limit >= 0:
+		op = as.ListGetByRankRangeCountOp(s.collectionBin, ranking.rank(), ranking.limit, as.ListReturnTypeValue)
+	default:
+		op = as.ListGetByRankRangeOp(s.collectionBin, 0, as.ListReturnTypeValue)
+	}
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.operateWithCtx(ctx, s.writePolicy(aSet, false), keys[0], []*as.Operation{op})
+	if err != nil {
+		return handleNotFoundError(err, rows)
+	}
+	values, _ := result.Bins[s.collectionBin].([]interface{})
+	if ranking != nil {
+		sort.SliceStable(values, func(i, j int) bool {
+			return ranking.less(values[i], values[j])
+		})
+		values = values[:ranking.count(len(values))]
+	}
+	records, err := s.listValueRecords(keys[0], values)
+	if err != nil {
+		return nil, err
+	}
+	rows.rowsReader = newRowsReader(records)
+	return rows, nil
+}
+
+// listValueRecords converts ranked list items to records, scalar items are mapped to the entry value column
+func (s *Statement) listValueRecords(key *as.Key, values []interface{}) ([]*as.Record, error) {
+	column := s.entryValueColumn()
+	var records []*as.Record
+	for _, value := range values {
+		record := &as.Record{Bins: map[string]interface{}{}}
+		if properties, ok := value.(map[interface{}]interface{}); ok {
+			for k, v := range properties {
+				record.Bins[k.(string)] = v
+			}
+		} else if column != "" {
+			record.Bins[column] = coerceScalarToFieldType(value, s.mapper.getField(column))
+		} else {
+			return nil, fmt.Errorf("invalid list item value: %v", value)
+		}
+		record.Bins[s.mapper.pk[0].Column()] = key.Value().GetObject()
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// rank returns starting rank of the ranking range
+func (r *valueRanking) rank() int {
+	if r.desc {
+		return -r.limit
+	}
+	return 0
+}
+
+// count returns number of ranked entries to return out of available ones
+func (r *valueRanking) count(available int) int {
+	if r.limit >= 0 && r.limit < available {
+		return r.limit
+	}
+	return available
+}
+
+// less reports ranking order of comparable values, values that can not be compared keep server rank order
+func (r *valueRanking) less(x, y interface{}) bool {
+	cmp, ok := compareValues(x, y)
+	if !ok {
+		return false
+	}
+	if r.desc {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// compareValues compares numeric or string values
+func compareValues(x, y interface{}) (int, bool) {
+	if xs, ok := x.(string); ok {
+		ys, ok := y.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(xs, ys), true
+	}
+	xf, ok := asFloat(x)
+	if !ok {
+		return 0, false
+	}
+	yf, ok := asFloat(y)
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case xf < yf:
+		return -1, true
+	case xf > yf:
+		return 1, true
+	}
+	return 0, true
+}
+
+func asFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// inclusiveRangeEnd converts inclusive BETWEEN end value to exclusive value range end
+func inclusiveRangeEnd(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case int:
+		return actual + 1
+	case int64:
+		return actual + 1
+	case int32:
+		return int64(actual) + 1
+	case float64:
+		return math.Nextafter(actual, math.Inf(1))
+	case float32:
+		return math.Nextafter(float64(actual), math.Inf(1))
+	case string:
+		return actual + "\x00"
+	}
+	return value
+}
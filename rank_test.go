@@ -0,0 +1,235 @@
+package aerospike
+
+import (
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlparser"
+	"reflect"
+	"testing"
+)
+
+func Test_valueRanking(t *testing.T) {
+	type Score struct {
+		Board  string `aerospike:"board,pk=true"`
+		Player string `aerospike:"player,mapKey"`
+		Score  int    `aerospike:"score"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Score{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      *valueRanking
+		expectErr   bool
+	}{
+		{
+			description: "top n by value column",
+			SQL:         "SELECT * FROM board$scores WHERE pk = ? ORDER BY score DESC LIMIT 3",
+			expect:      &valueRanking{desc: true, limit: 3},
+		},
+		{
+			description: "ascending by value column",
+			SQL:         "SELECT * FROM board$scores WHERE pk = ? ORDER BY score",
+			expect:      &valueRanking{limit: -1},
+		},
+		{
+			description: "order by value without value field",
+			SQL:         "SELECT * FROM board$scores WHERE pk = ? ORDER BY value DESC LIMIT 3",
+		},
+		{
+			description: "order by map key",
+			SQL:         "SELECT * FROM board$scores WHERE pk = ? ORDER BY player DESC LIMIT 3",
+		},
+		{
+			description: "no order by",
+			SQL:         "SELECT * FROM board$scores WHERE pk = ?",
+		},
+	}
+	for _, testCase := range testCases {
+		aQuery, err := sqlparser.ParseQuery(testCase.SQL)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		stmt := &Statement{mapper: aMapper, query: aQuery, collectionType: collectionTypeMap}
+		actual, err := stmt.valueRanking()
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func Test_valueRangeCriteria(t *testing.T) {
+	type Score struct {
+		Board  string `aerospike:"board,pk=true"`
+		Player string `aerospike:"player,mapKey"`
+		Score  int    `aerospike:"score"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Score{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	stmt := &Statement{mapper: aMapper, collectionType: collectionTypeMap}
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      *valueRange
+		expectErr   bool
+	}{
+		{
+			description: "value between",
+			SQL:         "SELECT * FROM board$scores WHERE pk = 'b1' AND score BETWEEN 10 AND 20",
+			expect:      &valueRange{begin: 10, end: 20},
+		},
+		{
+			description: "unsupported value operator",
+			SQL:         "SELECT * FROM board$scores WHERE pk = 'b1' AND score > 10",
+			expectErr:   true,
+		},
+		{
+			description: "value equal",
+			SQL:         "SELECT * FROM board$scores WHERE pk = 'b1' AND score = 10",
+			expect:      &valueRange{begin: 10, end: 10},
+		},
+		{
+			description: "map key is not value range",
+			SQL:         "SELECT * FROM board$scores WHERE pk = 'b1' AND player = 'p1'",
+		},
+	}
+	for _, testCase := range testCases {
+		aQuery, err := sqlparser.ParseQuery(testCase.SQL)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		stmt.valueRange = nil
+		err = stmt.updateCriteria(aQuery.Qualify, nil, true)
+		assert.Equal(t, testCase.expectErr, err != nil, testCase.description)
+		assert.Equal(t, testCase.expect, stmt.valueRange, testCase.description)
+	}
+}
+
+func Test_valueRanking_less(t *testing.T) {
+	desc := &valueRanking{desc: true, limit: 2}
+	assert.True(t, desc.less(3, 2.5))
+	assert.False(t, desc.less("a", "b"))
+	assert.False(t, desc.less(map[interface{}]interface{}{}, 1))
+	assert.Equal(t, -2, desc.rank())
+	assert.Equal(t, 2, desc.count(5))
+	assert.Equal(t, 1, desc.count(1))
+	asc := &valueRanking{limit: -1}
+	assert.True(t, asc.less("a", "b"))
+	assert.Equal(t, 0, asc.rank())
+	assert.Equal(t, 5, asc.count(5))
+}
+
+func Test_inclusiveRangeEnd(t *testing.T) {
+	assert.Equal(t, 11, inclusiveRangeEnd(10))
+	assert.Equal(t, int64(11), inclusiveRangeEnd(int64(10)))
+	assert.Equal(t, "abc\x00", inclusiveRangeEnd("abc"))
+	assert.True(t, inclusiveRangeEnd(1.5).(float64) > 1.5)
+}
+
+func Test_entryValueColumn(t *testing.T) {
+	type Score struct {
+		Board  string `aerospike:"board,pk=true"`
+		Player string `aerospike:"player,mapKey"`
+		Score  int    `aerospike:"score"`
+	}
+	type Entry struct {
+		Board  string `aerospike:"board,pk=true"`
+		Player string `aerospike:"player,mapKey"`
+		Score  int    `aerospike:"score"`
+		Level  int    `aerospike:"level"`
+	}
+	type Value struct {
+		Board  string `aerospike:"board,pk=true"`
+		Player string `aerospike:"player,mapKey"`
+	}
+	var testCases = []struct {
+		description string
+		rType       reflect.Type
+		expect      string
+	}{
+		{description: "scalar entry", rType: reflect.TypeOf(Score{}), expect: "score"},
+		{description: "object entry", rType: reflect.TypeOf(Entry{}), expect: ""},
+		{description: "no payload field", rType: reflect.TypeOf(Value{}), expect: "value"},
+	}
+	for _, testCase := range testCases {
+		aMapper, err := newTypeBasedMapper(testCase.rType)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		stmt := &Statement{mapper: aMapper, collectionType: collectionTypeMap}
+		assert.Equal(t, testCase.expect, stmt.entryValueColumn(), testCase.description)
+		if testCase.expect != "" {
+			assert.True(t, stmt.isValueColumn(testCase.expect), testCase.description)
+		}
+		assert.False(t, stmt.isValueColumn("player"), testCase.description)
+	}
+}
+
+func Test_listValueRecords(t *testing.T) {
+	type Score struct {
+		Board int `aerospike:"board,pk=true"`
+		Score int `aerospike:"score"`
+	}
+	type Entry struct {
+		Board int    `aerospike:"board,pk=true"`
+		Score int    `aerospike:"score"`
+		Name  string `aerospike:"name"`
+	}
+	key, err := as.NewKey("test", "boards", 7)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		rType       reflect.Type
+		values      []interface{}
+		expect      []map[string]interface{}
+		expectErr   bool
+	}{
+		{
+			description: "scalar items",
+			rType:       reflect.TypeOf(Score{}),
+			values:      []interface{}{30, 20},
+			expect:      []map[string]interface{}{{"board": 7, "score": 30}, {"board": 7, "score": 20}},
+		},
+		{
+			description: "object items",
+			rType:       reflect.TypeOf(Entry{}),
+			values:      []interface{}{map[interface{}]interface{}{"score": 30, "name": "a"}},
+			expect:      []map[string]interface{}{{"board": 7, "score": 30, "name": "a"}},
+		},
+		{
+			description: "scalar items of object entries",
+			rType:       reflect.TypeOf(Entry{}),
+			values:      []interface{}{30},
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		aMapper, err := newTypeBasedMapper(testCase.rType)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		stmt := &Statement{mapper: aMapper, collectionType: collectionTypeArray}
+		records, err := stmt.listValueRecords(key, testCase.values)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []map[string]interface{}
+		for _, record := range records {
+			actual = append(actual, record.Bins)
+		}
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
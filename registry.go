@@ -2,85 +2,148 @@ package aerospike
 
 import (
 	"fmt"
+	"github.com/viant/x"
 	"sync"
 )
 
 type (
-	//registry represents a set registry
-	registry struct {
-		mux   sync.RWMutex
-		types map[string]*set
+	// Registry represents a set registry, sets not registered in the registry are looked up in its parent registry
+	Registry struct {
+		mux    sync.RWMutex
+		types  map[string]*set
+		parent *Registry
 	}
 )
 
 // Register registers a set
-func (r *registry) Register(aSet *set) error {
+func (r *Registry) Register(aSet *set) error {
 	return r.register(aSet)
 }
 
-func (r *registry) clear() {
+// RegisterSet registers a set, registering an equivalent definition again is a no-op
+func (r *Registry) RegisterSet(xType *x.Type, options ...Option) error {
+	return r.register(newSet(xType, options...))
+}
+
+// ReplaceSet registers a set, replacing previous set definition with the same name
+func (r *Registry) ReplaceSet(xType *x.Type, options ...Option) error {
+	return r.replace(newSet(xType, options...))
+}
+
+// UnregisterSet removes a set, it returns false if the set was not registered in the registry
+func (r *Registry) UnregisterSet(name string) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.types[name]; !ok {
+		return false
+	}
+	delete(r.types, name)
+	return true
+}
+
+func (r *Registry) clear() {
 	r.mux.Lock()
 	r.types = make(map[string]*set)
 	r.mux.Unlock()
 
 }
 
-func (r *registry) sets() []string {
+func (r *Registry) sets() []string {
 	r.mux.RLock()
 	keys := make([]string, 0, len(r.types))
 	for key := range r.types {
 		keys = append(keys, key)
 	}
 	r.mux.RUnlock()
+	if r.parent != nil {
+		for _, key := range r.parent.sets() {
+			if !r.hasOwn(key) {
+				keys = append(keys, key)
+			}
+		}
+	}
 	return keys
 }
 
-func (r *registry) register(aSet *set) error {
-	if aSet == nil {
-		return fmt.Errorf("unable to register set: set is nil")
-	}
-	if aSet.xType.Name == "" {
-		return fmt.Errorf("unable to register set: set name is empty")
+func (r *Registry) register(aSet *set) error {
+	if err := checkSet(aSet); err != nil {
+		return fmt.Errorf("unable to register set: %w", err)
 	}
 
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
 	key := aSet.xType.Name
-	if _, exists := r.types[key]; exists {
-		return nil
+	if existing, exists := r.types[key]; exists {
+		if existing == aSet || existing.isEquivalent(aSet) {
+			return nil
+		}
+		return fmt.Errorf("unable to register set %v: already registered with different definition", key)
+	}
+	if err := aSet.validate(); err != nil {
+		return fmt.Errorf("unable to register set %v: %w", key, err)
+	}
+	r.types[key] = aSet
+	return nil
+}
+
+// replace registers a set in place of the previous definition, mappers cached by the previous set are not reused,
+// statements prepared with the previous definition rebuild their mappers on next execution
+func (r *Registry) replace(aSet *set) error {
+	if err := checkSet(aSet); err != nil {
+		return fmt.Errorf("unable to replace set: %w", err)
+	}
+	key := aSet.xType.Name
+	if err := aSet.validate(); err != nil {
+		return fmt.Errorf("unable to replace set %v: %w", key, err)
 	}
+	r.mux.Lock()
 	r.types[key] = aSet
+	r.mux.Unlock()
+	return nil
+}
+
+func checkSet(aSet *set) error {
+	if aSet == nil {
+		return fmt.Errorf("set is nil")
+	}
+	if aSet.xType == nil || aSet.xType.Name == "" {
+		return fmt.Errorf("set name is empty")
+	}
 	return nil
 }
 
 // Lookup returns a set by name
-func (r *registry) Lookup(name string) *set {
+func (r *Registry) Lookup(name string) *set {
 	r.mux.RLock()
 	aSet, _ := r.types[name]
 	r.mux.RUnlock()
+	if aSet == nil && r.parent != nil {
+		return r.parent.Lookup(name)
+	}
 	return aSet
 }
 
 // Has returns true if set is registered
-func (r *registry) Has(name string) bool {
+func (r *Registry) Has(name string) bool {
 	return r.Lookup(name) != nil
 }
 
-// newRegistry creates a registry
-func newRegistry() *registry {
-	ret := &registry{types: make(map[string]*set)}
-	return ret
+func (r *Registry) hasOwn(name string) bool {
+	r.mux.RLock()
+	_, ok := r.types[name]
+	r.mux.RUnlock()
+	return ok
 }
 
-// Merge merges registry
-func (r *registry) Merge(registry *registry) error {
-	registry.mux.RLock()
-	defer registry.mux.RUnlock()
-	for _, aSet := range registry.types {
-		if err := r.Register(aSet); err != nil {
-			return err
-		}
-	}
-	return nil
+// NewRegistry creates a set registry, use it with WithRegistry connector option to isolate sql.DB sets
+// from globally registered ones
+func NewRegistry() *Registry {
+	return newRegistry()
+}
+
+// newRegistry creates a registry
+func newRegistry() *Registry {
+	ret := &Registry{types: make(map[string]*set)}
+	return ret
 }
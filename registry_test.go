@@ -0,0 +1,236 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlparser"
+	"github.com/viant/x"
+	"reflect"
+	"testing"
+)
+
+func Test_setValidate(t *testing.T) {
+	type Valid struct {
+		Id     string `aerospike:"id,pk=true"`
+		Key    string `aerospike:"key,mapKey"`
+		Bucket int    `aerospike:"bucket,arrayIndex,arraySize=3"`
+		Count  int    `aerospike:"count,component"`
+	}
+	type Invalid struct {
+		Id       string `aerospike:"id,pk=true"`
+		Other    string `aerospike:"other,pk=true"`
+		Name     string `aerospike:"name"`
+		Alias    string `aerospike:"name"`
+		Count    int    `aerospike:"count,component"`
+		Gen      int    `aerospike:"gen,generation"`
+		Version  int    `aerospike:"version,generation"`
+		Price    bool   `aerospike:"price,scale=2"`
+		Note     string `aerospike:"note,unsupported"`
+		Lifetime int    `aerospike:"customer_lifetime_value"`
+	}
+	var testCases = []struct {
+		description      string
+		rType            reflect.Type
+		expectViolations int
+	}{
+		{description: "valid", rType: reflect.TypeOf(Valid{})},
+		{description: "every violation", rType: reflect.TypeOf(Invalid{}), expectViolations: 7},
+		{description: "non struct", rType: reflect.TypeOf(""), expectViolations: 1},
+	}
+	for _, testCase := range testCases {
+		err := newRegistry().Register(&set{xType: x.NewType(testCase.rType, x.WithName("records"))})
+		if testCase.expectViolations == 0 {
+			assert.Nil(t, err, testCase.description)
+			continue
+		}
+		definitionErr := &DefinitionError{}
+		if !assert.True(t, errors.As(err, &definitionErr), testCase.description) {
+			continue
+		}
+		assert.Equal(t, "records", definitionErr.Set, testCase.description)
+		assert.Len(t, definitionErr.Violations, testCase.expectViolations, testCase.description)
+	}
+}
+
+func Test_registryDuplicates(t *testing.T) {
+	type Foo struct {
+		Id   int
+		Name string
+	}
+	type Bar struct {
+		Id   int
+		Name string
+	}
+	type Baz struct {
+		Id   int
+		Desc string
+	}
+	newSet := func(rType reflect.Type, options ...Option) *set {
+		aSet := &set{xType: x.NewType(rType, x.WithName("foo"))}
+		for _, option := range options {
+			option(aSet)
+		}
+		return aSet
+	}
+	aRegistry := newRegistry()
+	aSet := newSet(reflect.TypeOf(Foo{}))
+	assert.Nil(t, aRegistry.Register(aSet))
+	assert.Nil(t, aRegistry.Register(aSet), "same set")
+	assert.Nil(t, aRegistry.Register(newSet(reflect.TypeOf(Foo{}))), "same definition")
+	assert.Nil(t, aRegistry.Register(newSet(reflect.TypeOf(Bar{}))), "equivalent type")
+	assert.NotNil(t, aRegistry.Register(newSet(reflect.TypeOf(Baz{}))), "different type")
+	assert.NotNil(t, aRegistry.Register(newSet(reflect.TypeOf(Foo{}), WithTTLSec(10))), "different options")
+
+	global := newRegistry()
+	assert.Nil(t, global.Register(newSet(reflect.TypeOf(Baz{}))))
+	aRegistry.parent = global
+	assert.Equal(t, aSet, aRegistry.Lookup("foo"), "local set shadows parent one")
+	assert.True(t, aRegistry.UnregisterSet("foo"))
+	assert.False(t, aRegistry.UnregisterSet("foo"))
+	assert.Equal(t, reflect.TypeOf(Baz{}), aRegistry.Lookup("foo").xType.Type, "parent set")
+}
+
+func Test_registryReplace(t *testing.T) {
+	type Foo struct {
+		Id   int
+		Name string
+	}
+	type Baz struct {
+		Id   int
+		Desc string
+	}
+	aRegistry := NewRegistry()
+	assert.Nil(t, aRegistry.RegisterSet(x.NewType(reflect.TypeOf(Foo{}), x.WithName("foo"))))
+	stmt := &Statement{SQL: "SELECT * FROM foo", set: "foo", sets: aRegistry}
+	if !assert.Nil(t, stmt.setTypeBasedMapper(context.Background())) {
+		return
+	}
+	assert.NotNil(t, stmt.mapper.getField("Name"))
+
+	replace := &Statement{SQL: "REPLACE SET WITH TTL 10 foo AS ?", kind: parseKind("REPLACE SET WITH TTL 10 foo AS ?"), sets: aRegistry}
+	_, err := replace.ExecContext(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: Baz{}}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, 10, aRegistry.Lookup("foo").ttlSec)
+	assert.Nil(t, stmt.refreshTypeBasedMapper(context.Background()))
+	assert.Nil(t, stmt.mapper.getField("Name"), "replaced set mapper")
+	assert.NotNil(t, stmt.mapper.getField("Desc"), "replaced set mapper")
+
+	unregister := &Statement{kind: parseKind("UNREGISTER SET foo"), sets: aRegistry}
+	if !assert.Nil(t, unregister.prepareUnregisterSet("UNREGISTER SET foo")) {
+		return
+	}
+	_, err = unregister.ExecContext(context.Background(), nil)
+	assert.Nil(t, err)
+	_, err = unregister.ExecContext(context.Background(), nil)
+	assert.NotNil(t, err, "set is not registered")
+	assert.NotNil(t, stmt.refreshTypeBasedMapper(context.Background()), "unregistered set")
+}
+
+func Test_registryGlobalReplace(t *testing.T) {
+	type Foo struct {
+		Id   int
+		Name string
+	}
+	type Baz struct {
+		Id   int
+		Desc string
+	}
+	newConnectionSets := func() *Registry {
+		sets := newRegistry()
+		sets.parent = globalSets
+		return sets
+	}
+	exec := func(sets *Registry, SQL string, args ...driver.NamedValue) error {
+		stmt := &Statement{SQL: SQL, kind: parseKind(SQL), sets: sets}
+		if stmt.kind == kindUnregisterSet {
+			if err := stmt.prepareUnregisterSet(SQL); err != nil {
+				return err
+			}
+		}
+		_, err := stmt.ExecContext(context.Background(), args)
+		return err
+	}
+	defer globalSets.UnregisterSet("globalFoo")
+	first, second := newConnectionSets(), newConnectionSets()
+	if !assert.Nil(t, exec(first, "REGISTER GLOBAL SET globalFoo AS ?", driver.NamedValue{Ordinal: 1, Value: Foo{}})) {
+		return
+	}
+	assert.False(t, first.hasOwn("globalFoo"), "global set is not copied to connection registry")
+	stmt := &Statement{SQL: "SELECT * FROM globalFoo", set: "globalFoo", sets: first}
+	if !assert.Nil(t, stmt.setTypeBasedMapper(context.Background())) {
+		return
+	}
+
+	if !assert.Nil(t, exec(second, "REPLACE GLOBAL SET globalFoo AS ?", driver.NamedValue{Ordinal: 1, Value: Baz{}})) {
+		return
+	}
+	assert.Equal(t, reflect.TypeOf(Baz{}), first.Lookup("globalFoo").xType.Type, "replaced by other connection")
+	assert.Nil(t, stmt.refreshTypeBasedMapper(context.Background()))
+	assert.NotNil(t, stmt.mapper.getField("Desc"), "replaced global set mapper")
+
+	assert.NotNil(t, exec(first, "UNREGISTER SET globalFoo"), "global set requires UNREGISTER GLOBAL SET")
+	assert.Nil(t, exec(second, "UNREGISTER GLOBAL SET globalFoo"))
+	assert.Nil(t, first.Lookup("globalFoo"), "unregistered by other connection")
+}
+
+func Test_parseKind(t *testing.T) {
+	var testCases = []struct {
+		SQL          string
+		expect       sqlparser.Kind
+		expectSQL    string
+		expectGlobal bool
+	}{
+		{SQL: "UNREGISTER SET Foo", expect: kindUnregisterSet},
+		{SQL: "unregister global set Foo;", expect: kindUnregisterSet, expectGlobal: true},
+		{SQL: "UPDATE Foo SET name = 'x'", expect: sqlparser.KindUpdate},
+		{SQL: "REPLACE SET Foo AS ?", expect: sqlparser.KindRegisterSet, expectSQL: "REGISTER SET Foo AS ?"},
+		{SQL: "replace global set WITH TTL 5 Foo AS ?", expect: sqlparser.KindRegisterSet, expectSQL: "REGISTER global set WITH TTL 5 Foo AS ?"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, parseKind(testCase.SQL), testCase.SQL)
+		if testCase.expect == kindUnregisterSet {
+			stmt := &Statement{}
+			if assert.Nil(t, stmt.prepareUnregisterSet(testCase.SQL), testCase.SQL) {
+				assert.Equal(t, "Foo", stmt.unregister.Name, testCase.SQL)
+				assert.Equal(t, testCase.expectGlobal, stmt.unregister.Global, testCase.SQL)
+			}
+		}
+		if testCase.expectSQL != "" {
+			assert.Equal(t, testCase.expectSQL, asRegisterSet(testCase.SQL), testCase.SQL)
+		}
+	}
+}
+
+func Test_checkCollectionBin(t *testing.T) {
+	type Record struct {
+		Id    string `aerospike:"id,pk=true"`
+		Key   string `aerospike:"key,mapKey"`
+		Count int    `aerospike:"count"`
+	}
+	aRegistry := NewRegistry()
+	if !assert.Nil(t, aRegistry.RegisterSet(x.NewType(reflect.TypeOf(Record{}), x.WithName("records")))) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		kind        sqlparser.Kind
+		source      string
+		expectErr   bool
+	}{
+		{description: "select with bin", kind: sqlparser.KindSelect, source: "records/values"},
+		{description: "select without bin", kind: sqlparser.KindSelect, source: "records", expectErr: true},
+		{description: "update without bin", kind: sqlparser.KindUpdate, source: "records", expectErr: true},
+		{description: "insert without bin", kind: sqlparser.KindInsert, source: "records"},
+		{description: "delete without bin", kind: sqlparser.KindDelete, source: "records"},
+	}
+	for _, testCase := range testCases {
+		stmt := &Statement{kind: testCase.kind, sets: aRegistry}
+		stmt.setSet(testCase.source)
+		err := stmt.setTypeBasedMapper(context.Background())
+		assert.Equal(t, testCase.expectErr, err != nil, testCase.description)
+	}
+}
@@ -0,0 +1,219 @@
+package aerospike
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/viant/sqlparser"
+)
+
+const returningKeyword = "returning"
+
+// splitReturning removes trailing RETURNING clause from SQL and returns its columns
+func splitReturning(SQL string) (string, []string) {
+	lower := strings.ToLower(SQL)
+	inQuote := false
+	pos := -1
+	for i := 0; i < len(lower); i++ {
+		switch lower[i] {
+		case '\'':
+			inQuote = !inQuote
+		case 'r':
+			if inQuote || !strings.HasPrefix(lower[i:], returningKeyword) {
+				continue
+			}
+			if i == 0 || !isSpace(lower[i-1]) {
+				continue
+			}
+			if end := i + len(returningKeyword); end < len(lower) && !isSpace(lower[end]) {
+				continue
+			}
+			pos = i
+		}
+	}
+	if pos == -1 {
+		return SQL, nil
+	}
+	var columns []string
+	for _, column := range strings.Split(SQL[pos+len(returningKeyword):], ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, strings.Trim(column, "`"))
+		}
+	}
+	return strings.TrimSpace(SQL[:pos]), columns
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isReturningAll returns true if RETURNING * was used
+func (s *Statement) isReturningAll() bool {
+	return len(s.returning) == 1 && s.returning[0] == "*"
+}
+
+// returningOperations returns read operations for RETURNING bins
+func (s *Statement) returningOperations() []*as.Operation {
+	if s.isReturningAll() {
+		return []*as.Operation{as.GetOp()}
+	}
+	var result []*as.Operation
+	unique := map[string]bool{}
+	for _, column := range s.returning {
+		if isPseudoColumn(column) {
+			continue
+		}
+		aField := s.mapper.getField(column)
+		if aField == nil || aField.tag.IsGeneration || aField.tag.IsTTL || unique[aField.Column()] {
+			continue
+		}
+		unique[aField.Column()] = true
+		result = append(result, as.GetBinOp(aField.Column()))
+	}
+	return result
+}
+
+// appendReturning appends RETURNING read operations, written bins are used to extract read result out of per operation results
+func (s *Statement) appendReturning(writePolicy *as.WritePolicy, ops []*as.Operation) []*as.Operation {
+	if len(s.returning) == 0 {
+		return ops
+	}
+	writePolicy.RespondPerEachOp = true
+	return append(ops, s.returningOperations()...)
+}
+
+// appendReturningMapEntries appends RETURNING map entry read operations for supplied map keys
+func (s *Statement) appendReturningMapEntries(writePolicy *as.WritePolicy, ops []*as.Operation, mapKeys []interface{}) []*as.Operation {
+	if len(s.returning) == 0 {
+		return ops
+	}
+	writePolicy.RespondPerEachOp = true
+	for _, mapKey := range mapKeys {
+		ops = append(ops, as.MapGetByKeyOp(s.collectionBin, mapKey, as.MapReturnType.VALUE))
+	}
+	return ops
+}
+
+// ensureReturningSupported checks if RETURNING clause can be used with the statement set
+func (s *Statement) ensureReturningSupported() error {
+	if len(s.returning) == 0 {
+		return nil
+	}
+	if s.mapper.component != nil || s.collectionType.IsArray() {
+		return fmt.Errorf("RETURNING is not supported for %v set with %v collection", s.set, s.collectionType)
+	}
+	return nil
+}
+
+// addReturned adds operate result bins as a RETURNING record
+func (s *Statement) addReturned(result *as.Record, written map[string]bool) {
+	if len(s.returning) == 0 || result == nil {
+		return
+	}
+	bins := make(as.BinMap, len(result.Bins))
+	for bin, value := range result.Bins {
+		if written[bin] {
+			value = lastResult(value)
+		}
+		bins[bin] = value
+	}
+	s.appendReturned(&as.Record{Key: result.Key, Bins: bins, Generation: result.Generation, Expiration: result.Expiration})
+}
+
+// addReturnedMapEntries adds trailing map entry read results as RETURNING records
+func (s *Statement) addReturnedMapEntries(result *as.Record, pkValue interface{}, mapKeys []interface{}) error {
+	if len(s.returning) == 0 || result == nil {
+		return nil
+	}
+	values, ok := result.Bins[s.collectionBin].([]interface{})
+	if !ok || len(values) < len(mapKeys) {
+		return fmt.Errorf("unable to read returning values of %v bin", s.collectionBin)
+	}
+	values = values[len(values)-len(mapKeys):]
+	for i, mapKey := range mapKeys {
+		bins := s.mapEntryBins(pkValue, mapKey, values[i])
+		s.appendReturned(&as.Record{Key: result.Key, Bins: bins, Generation: result.Generation, Expiration: result.Expiration})
+	}
+	return nil
+}
+
+func (s *Statement) appendReturned(record *as.Record) {
+	s.returnedMux.Lock()
+	s.returned = append(s.returned, record)
+	s.returnedMux.Unlock()
+}
+
+// mapEntryBins converts map bin entry value into record bins
+func (s *Statement) mapEntryBins(pkValue interface{}, mapKey interface{}, entry interface{}) as.BinMap {
+	bins := as.BinMap{}
+	if object, ok := entry.(map[interface{}]interface{}); ok {
+		for k, v := range object {
+			if key, ok := k.(string); ok {
+				bins[key] = v
+			}
+		}
+	} else if payload := findPayloadColumn(s.mapper); payload != "" {
+		bins[payload] = coerceScalarToFieldType(entry, s.mapper.getField(payload))
+	}
+	if len(s.mapper.pk) > 0 {
+		bins[s.mapper.pk[0].Column()] = pkValue
+	}
+	if len(s.mapper.mapKey) > 0 {
+		bins[s.mapper.mapKey[0].Column()] = mapKey
+	}
+	return bins
+}
+
+// lastResult returns the last of per operation results
+func lastResult(value interface{}) interface{} {
+	if values, ok := value.([]interface{}); ok && len(values) > 0 {
+		return values[len(values)-1]
+	}
+	return value
+}
+
+// newReturningMapper creates mapper for RETURNING columns
+func (s *Statement) newReturningMapper() (*mapper, error) {
+	if s.isReturningAll() {
+		return s.mapper, nil
+	}
+	ret := &mapper{
+		fields: make([]field, 0),
+		byName: make(map[string]int),
+		pk:     s.mapper.pk,
+		mapKey: s.mapper.mapKey,
+	}
+	for _, column := range s.returning {
+		if err := ret.appendField(s.recordType, column, s.mapper, false, false, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// executeReturning executes insert or update statement and returns RETURNING values as rows
+func (s *Statement) executeReturning(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if len(s.returning) == 0 {
+		return nil, fmt.Errorf("unsupported parameterizedQuery type: %v without RETURNING clause", s.kind)
+	}
+	aMapper, err := s.newReturningMapper()
+	if err != nil {
+		return nil, err
+	}
+	s.returned = nil
+	switch s.kind {
+	case sqlparser.KindInsert:
+		err = s.handleInsert(ctx, args)
+	default:
+		err = s.handleUpdate(ctx, args)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rows := s.newRows(ctx, aMapper)
+	rows.rowsReader = newRowsReader(s.returned)
+	return rows, nil
+}
@@ -0,0 +1,48 @@
+package aerospike
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_splitReturning(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expectSQL   string
+		expect      []string
+	}{
+		{
+			description: "no returning",
+			SQL:         "UPDATE users SET name = ? WHERE pk = ?",
+			expectSQL:   "UPDATE users SET name = ? WHERE pk = ?",
+		},
+		{
+			description: "returning columns",
+			SQL:         "UPDATE users SET counter = counter + 1 WHERE pk = ? RETURNING counter, `name`",
+			expectSQL:   "UPDATE users SET counter = counter + 1 WHERE pk = ?",
+			expect:      []string{"counter", "name"},
+		},
+		{
+			description: "returning all",
+			SQL:         "INSERT INTO users(id,name) VALUES(?,?) returning *",
+			expectSQL:   "INSERT INTO users(id,name) VALUES(?,?)",
+			expect:      []string{"*"},
+		},
+		{
+			description: "returning keyword within literal",
+			SQL:         "UPDATE users SET name = ' returning x' WHERE pk = ?",
+			expectSQL:   "UPDATE users SET name = ' returning x' WHERE pk = ?",
+		},
+		{
+			description: "returning prefixed column",
+			SQL:         "UPDATE users SET returning_count = ? WHERE pk = ?",
+			expectSQL:   "UPDATE users SET returning_count = ? WHERE pk = ?",
+		},
+	}
+	for _, testCase := range testCases {
+		actualSQL, actual := splitReturning(testCase.SQL)
+		assert.Equal(t, testCase.expectSQL, actualSQL, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -24,6 +24,9 @@ type Rows struct {
 	rowsReader    rowsIterator
 	processedRows uint64
 	ctx           context.Context
+	loadChunks    func(ctx context.Context, record *as.Record) error
+	keyProvider   KeyProvider
+	set           string
 }
 
 // Columns returns parameterizedQuery columns
@@ -52,6 +55,11 @@ func (r *Rows) Next(dest []driver.Value) error {
 	if err != nil {
 		return err
 	}
+	if r.loadChunks != nil {
+		if err = r.loadChunks(r.ctx, record); err != nil {
+			return err
+		}
+	}
 
 	//reset record with nil, or 0 values
 	copy(unsafe.Slice((*byte)(xunsafe.AsPointer(r.record)), r.recordType.Size()), r.zeroRecord)
@@ -76,15 +84,109 @@ func (r *Rows) transferBinValues(dest []driver.Value, record *as.Record, ptr uns
 			dest[i] = record.Bins[aField.Column()]
 			continue
 		}
+		if aField.isMeta {
+			dest[i] = pseudoColumnValue(record, aField.Column())
+			continue
+		}
+		if len(aField.path) > 0 {
+			value, err := aField.pathValue(record.Bins)
+			if err != nil {
+				return err
+			}
+			dest[i] = toDriverValue(value)
+			continue
+		}
 		value, ok := record.Bins[aField.Column()]
-		if !ok || (ok && value == nil && aField.Type.Kind() == reflect.Slice) {
-			if aField.Type.Kind() == reflect.Slice {
+		if !ok && aField.tag.IsPK && record.Key != nil && record.Key.Value() != nil {
+			//records without pk bin expose user key stored with SendKey write policy
+			value, ok = record.Key.Value().GetObject(), true
+		}
+		if ok && aField.tag.IsEncrypted {
+			var err error
+			if value, err = aField.decrypt(r.keyProvider, value); err != nil {
+				return err
+			}
+		}
+		if ok && aField.tag.Compress != "" {
+			var err error
+			if value, err = aField.decompress(value); err != nil {
+				return err
+			}
+		}
+		if ok && aField.tag.Codec != "" {
+			decoded, err := aField.decode(value)
+			if err != nil {
+				return codecError(r.set, &aField, err)
+			}
+			aField.SetValue(ptr, decoded)
+			dest[i] = decoded
+			continue
+		}
+		if aField.tag.IsGeneration {
+			value, ok = pseudoColumnValue(record, generationColumn), true
+		} else if aField.tag.IsTTL {
+			value, ok = pseudoColumnValue(record, ttlColumn), true
+		}
+		if !ok || value == nil {
+			// missing bins are NULL for nullable fields, otherwise field type zero value
+			if aField.isNullable() {
+				dest[i] = nil
+			} else {
 				dest[i] = reflect.Zero(aField.Type).Interface()
-				continue
 			}
 			continue
 		}
 
+		if aField.tag.IsDecimal {
+			decoded, err := aField.decodeDecimal(value)
+			if err != nil {
+				return err
+			}
+			aField.SetValue(ptr, decoded)
+			dest[i] = toDriverValue(decoded)
+			continue
+		}
+
+		if conv := lookupConverter(aField.Type); conv != nil && conv.fromBin != nil {
+			converted, err := conv.convertFromBin(value, aField.Type)
+			if err != nil {
+				return fmt.Errorf("unable to convert %v: %w", aField.Column(), err)
+			}
+			aField.SetValue(ptr, converted)
+			if dest[i], err = convertedDriverValue(converted); err != nil {
+				return fmt.Errorf("unable to convert %v: %w", aField.Column(), err)
+			}
+			continue
+		}
+
+		if aField.tag.hasTimeEncoding() && isTimeType(aField.Type) {
+			ts, err := aField.setTime(ptr, value)
+			if err != nil {
+				return err
+			}
+			dest[i] = ts
+			continue
+		}
+
+		if isNestedStruct(aField.Type) {
+			value, err := aField.decodeValue(value)
+			if err != nil {
+				return err
+			}
+			aField.SetValue(ptr, value)
+			dest[i] = value
+			continue
+		}
+		if kind := reflect.ValueOf(value).Kind(); isCollectionType(aField.Type) && (kind == reflect.Slice || kind == reflect.Map) && reflect.TypeOf(value) != aField.Type {
+			value, err := aField.decodeValue(value)
+			if err != nil {
+				return err
+			}
+			aField.SetValue(ptr, value)
+			dest[i] = toDriverValue(aField.Value(ptr))
+			continue
+		}
+
 		// Special-case for byte slice-like fields (e.g., []byte, json.RawMessage, or pointers to these)
 		// Ensure assignment to struct fields uses the correct concrete type and surface []byte to SQL layer.
 		if (aField.Type.Kind() == reflect.Slice && aField.Type.Elem().Kind() == reflect.Uint8) ||
@@ -129,6 +231,24 @@ func (r *Rows) transferBinValues(dest []driver.Value, record *as.Record, ptr uns
 		}
 
 		srcType := reflect.TypeOf(value)
+		targetType := aField.Type
+		if targetType.Kind() == reflect.Ptr {
+			targetType = targetType.Elem()
+		}
+		if srcType != targetType && isNumericKind(srcType.Kind()) && isNumericKind(targetType.Kind()) {
+			converted, err := coerceNumber(value, targetType)
+			if err != nil {
+				return fmt.Errorf("unable to convert %v: %w", aField.Column(), err)
+			}
+			if aField.Type.Kind() == reflect.Ptr {
+				target := reflect.New(targetType)
+				target.Elem().Set(reflect.ValueOf(converted))
+				converted = target.Interface()
+			}
+			aField.SetValue(ptr, converted)
+			dest[i] = toDriverValue(aField.Value(ptr))
+			continue
+		}
 		if srcType == aField.Type {
 			aField.Set(ptr, value)
 		} else if srcType.AssignableTo(aField.Type) {
@@ -159,6 +279,28 @@ func (r *Rows) transferBinValues(dest []driver.Value, record *as.Record, ptr uns
 }
 
 // toDriverValue ensures values placed into dest[] are basic driver types, not pointers
+// convertedDriverValue returns driver value of converter converted field value: pointers are dereferenced,
+// driver.Valuer values use their driver value and byte slice based values (i.e. json.RawMessage) are returned as []byte copy
+func convertedDriverValue(value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return append([]byte(nil), v.Bytes()...), nil
+	}
+	return v.Interface(), nil
+}
+
 func toDriverValue(v interface{}) interface{} {
 	if v == nil {
 		return nil
@@ -232,11 +374,7 @@ func (r *Rows) ColumnTypeDatabaseTypeName(index int) string {
 // ColumnTypeNullable returns if column is nullable
 func (r *Rows) ColumnTypeNullable(index int) (nullable, ok bool) {
 	if index < len(r.mapper.fields) {
-		fType := r.mapper.fields[index].Type
-		if fType.Kind() == reflect.Ptr {
-			return true, true
-		}
-		return false, true
+		return r.mapper.fields[index].isNullable(), true
 	}
 	return false, false
 }
@@ -0,0 +1,266 @@
+package aerospike
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/aerospike/aerospike-client-go/v6/types"
+	"github.com/viant/x"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Schema represents declarative sets definition, loaded with schema=/path/sets.yaml (or .json) DSN parameter
+	Schema struct {
+		Sets []*SetSchema `json:"sets" yaml:"sets"`
+	}
+
+	// SetSchema represents set definition
+	SetSchema struct {
+		// Name is set name
+		Name string `json:"name" yaml:"name"`
+		// Spec is set record struct spec, i.e. struct{Id int `aerospike:"id,pk"`; Name string `aerospike:"name"`}
+		Spec string `json:"spec" yaml:"spec"`
+		// TTL is set record time to live in seconds
+		TTL uint32 `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+		// CollectionBin registers set as name/collectionBin
+		CollectionBin string `json:"collectionBin,omitempty" yaml:"collectionBin,omitempty"`
+		// KeyType declares expected PK type: string, int or bytes
+		KeyType string `json:"keyType,omitempty" yaml:"keyType,omitempty"`
+		// Options are REGISTER SET options without WITH keyword, i.e. MAP ORDER KEY_VALUE, LIST UNIQUE or BIN ALIASING
+		Options []string `json:"options,omitempty" yaml:"options,omitempty"`
+		// Indexes are secondary indexes created when schema is loaded
+		Indexes []*IndexSchema `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	}
+
+	// IndexSchema represents secondary index definition
+	IndexSchema struct {
+		Name string `json:"name" yaml:"name"`
+		Bin  string `json:"bin" yaml:"bin"`
+		// Type is index type: numeric, string or geo2dsphere
+		Type string `json:"type" yaml:"type"`
+	}
+)
+
+// LoadSchema loads schema from yaml or json file, files with .json extension are decoded as json
+func LoadSchema(location string) (*Schema, error) {
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load schema %v: %w", location, err)
+	}
+	schema := &Schema{}
+	if strings.EqualFold(path.Ext(location), ".json") {
+		err = json.Unmarshal(data, schema)
+	} else {
+		err = yaml.Unmarshal(data, schema)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode schema %v: %w", location, err)
+	}
+	return schema, nil
+}
+
+// Register registers schema sets
+func (s *Schema) Register(registry *Registry) error {
+	for _, setSchema := range s.Sets {
+		aSet, err := setSchema.newSet()
+		if err != nil {
+			return err
+		}
+		if err = registry.Register(aSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetName returns registered set name
+func (s *SetSchema) SetName() string {
+	if s.CollectionBin == "" {
+		return s.Name
+	}
+	return s.Name + "/" + s.CollectionBin
+}
+
+func (s *SetSchema) newSet() (*set, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("invalid schema set: name was empty")
+	}
+	if strings.TrimSpace(s.Spec) == "" {
+		return nil, fmt.Errorf("invalid schema set %v: spec was empty", s.Name)
+	}
+	rType, err := loadSpecType(s.Name, strings.TrimSpace(s.Spec))
+	if err != nil {
+		return nil, err
+	}
+	aSet := &set{xType: x.NewType(rType, x.WithName(s.SetName())), ttlSec: s.TTL}
+	for _, option := range s.Options {
+		header, options, err := extractCollectionOptions(" WITH " + strings.TrimSpace(option))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema set %v option %v: %w", s.Name, option, err)
+		}
+		if strings.TrimSpace(header) != "" || len(options) == 0 {
+			return nil, fmt.Errorf("invalid schema set %v: unsupported option %v", s.Name, option)
+		}
+		for _, opt := range options {
+			opt(aSet)
+		}
+	}
+	if s.KeyType != "" {
+		if err = s.checkKeyType(rType); err != nil {
+			return nil, err
+		}
+	}
+	return aSet, nil
+}
+
+// checkKeyType returns error if spec PK field type does not match declared key type
+func (s *SetSchema) checkKeyType(rType reflect.Type) error {
+	aMapper, err := newTypeBasedMapper(rType)
+	if err != nil {
+		return fmt.Errorf("invalid schema set %v: %w", s.Name, err)
+	}
+	if len(aMapper.pk) == 0 {
+		return fmt.Errorf("invalid schema set %v: key type %v declared, but spec has no PK field", s.Name, s.KeyType)
+	}
+	pkType := aMapper.pk[0].Type
+	for pkType.Kind() == reflect.Ptr {
+		pkType = pkType.Elem()
+	}
+	var ok bool
+	switch strings.ToLower(s.KeyType) {
+	case "string":
+		ok = pkType.Kind() == reflect.String
+	case "int", "integer":
+		ok = isIntKind(pkType.Kind())
+	case "bytes", "blob":
+		ok = pkType.Kind() == reflect.Slice && pkType.Elem().Kind() == reflect.Uint8
+	default:
+		return fmt.Errorf("invalid schema set %v: unsupported key type %v, supported(string, int, bytes)", s.Name, s.KeyType)
+	}
+	if !ok {
+		return fmt.Errorf("invalid schema set %v: PK field %v type %v does not match key type %v", s.Name, aMapper.pk[0].Name, pkType, s.KeyType)
+	}
+	return nil
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+type (
+	// schemaRegistries holds registries of DSN schema sets, so that pooled connections share sets and indexes loaded once
+	schemaRegistries struct {
+		mux        sync.Mutex
+		registries map[schemaKey]*schemaRegistry
+		dsn        map[*Registry]string
+	}
+
+	// schemaRegistry represents lazily loaded DSN schema sets registry
+	schemaRegistry struct {
+		mux      sync.Mutex
+		registry *Registry
+	}
+
+	// schemaKey identifies schema registry by DSN and parent registry of connection sets
+	schemaKey struct {
+		dsn    string
+		parent *Registry
+	}
+)
+
+var dsnSchemas = &schemaRegistries{registries: map[schemaKey]*schemaRegistry{}, dsn: map[*Registry]string{}}
+
+// registry returns registry of DSN schema sets with supplied parent, schema sets are registered and indexes created
+// by the first connection, failed loads are retried by the next connection
+func (r *schemaRegistries) registry(ctx context.Context, c *connection, dsn string, parent *Registry) (*Registry, error) {
+	r.mux.Lock()
+	if parent != nil && r.dsn[parent] == dsn {
+		r.mux.Unlock()
+		return parent, nil
+	}
+	key := schemaKey{dsn: dsn, parent: parent}
+	entry, ok := r.registries[key]
+	if !ok {
+		entry = &schemaRegistry{}
+		r.registries[key] = entry
+	}
+	r.mux.Unlock()
+
+	entry.mux.Lock()
+	defer entry.mux.Unlock()
+	if entry.registry != nil {
+		return entry.registry, nil
+	}
+	registry := newRegistry()
+	registry.parent = parent
+	if err := c.loadSchema(ctx, registry); err != nil {
+		return nil, err
+	}
+	entry.registry = registry
+	r.mux.Lock()
+	r.dsn[registry] = dsn
+	r.mux.Unlock()
+	return registry, nil
+}
+
+// loadSchema registers connection DSN schema sets in registry and creates their secondary indexes
+func (c *connection) loadSchema(ctx context.Context, registry *Registry) error {
+	schema, err := LoadSchema(c.cfg.schema)
+	if err != nil {
+		return err
+	}
+	if err = schema.Register(registry); err != nil {
+		return fmt.Errorf("unable to register schema %v sets: %w", c.cfg.schema, err)
+	}
+	stmt := &Statement{client: c.client, cfg: c.cfg, namespace: c.cfg.namespace, sets: registry}
+	for _, setSchema := range schema.Sets {
+		for _, index := range setSchema.Indexes {
+			if err = stmt.createSchemaIndex(ctx, setSchema.Name, index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createSchemaIndex creates secondary index, existing index is left intact
+func (s *Statement) createSchemaIndex(ctx context.Context, setName string, index *IndexSchema) error {
+	var indexType as.IndexType
+	switch strings.ToLower(index.Type) {
+	case "numeric":
+		indexType = as.NUMERIC
+	case "string":
+		indexType = as.STRING
+	case "geo2dsphere":
+		indexType = as.GEO2DSPHERE
+	default:
+		return fmt.Errorf("invalid schema set %v index %v: unsupported type %v", setName, index.Name, index.Type)
+	}
+	if index.Name == "" || index.Bin == "" {
+		return fmt.Errorf("invalid schema set %v index: name and bin are required", setName)
+	}
+	task, err := s.createIndexWithCtx(ctx, nil, s.namespace, setName, index.Name, index.Bin, indexType)
+	if err != nil {
+		if hasResultCode(err, types.INDEX_FOUND) {
+			return nil
+		}
+		return fmt.Errorf("unable to create schema set %v index %v: %w", setName, index.Name, err)
+	}
+	if err = <-task.OnComplete(); err != nil {
+		return fmt.Errorf("unable to create schema set %v index %v: %w", setName, index.Name, err)
+	}
+	return nil
+}
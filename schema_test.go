@@ -0,0 +1,121 @@
+package aerospike
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path"
+	"testing"
+)
+
+func Test_LoadSchema(t *testing.T) {
+	var testCases = []struct {
+		description string
+		file        string
+		content     string
+		expectSets  []string
+		expectTTL   uint32
+		expectErr   bool
+	}{
+		{
+			description: "yaml",
+			file:        "sets.yaml",
+			content: `sets:
+  - name: users
+    spec: 'struct{Id string ` + "`aerospike:\"id,pk\"`" + `; Email string ` + "`aerospike:\"email\"`" + `}'
+    ttl: 60
+    keyType: string
+    indexes:
+      - name: UserEmail
+        bin: email
+        type: string
+  - name: Board
+    collectionBin: scores
+    spec: 'struct{Id int ` + "`aerospike:\"id,pk\"`" + `; Player string ` + "`aerospike:\"player,mapKey\"`" + `; Score int ` + "`aerospike:\"score\"`" + `}'
+    options:
+      - MAP ORDER KEY_VALUE
+`,
+			expectSets: []string{"users", "Board/scores"},
+			expectTTL:  60,
+		},
+		{
+			description: "json",
+			file:        "sets.json",
+			content:     `{"sets": [{"name": "users", "ttl": 60, "keyType": "int", "spec": "struct{Id int; Name string}"}]}`,
+			expectSets:  []string{"users"},
+			expectTTL:   60,
+		},
+		{
+			description: "key type mismatch",
+			file:        "sets.json",
+			content:     `{"sets": [{"name": "users", "keyType": "string", "spec": "struct{Id int; Name string}"}]}`,
+			expectErr:   true,
+		},
+		{
+			description: "unsupported option",
+			file:        "sets.yaml",
+			content:     "sets:\n  - name: users\n    spec: struct{Id int; Name string}\n    options: [MAP SIZE 4]\n",
+			expectErr:   true,
+		},
+		{
+			description: "invalid spec",
+			file:        "sets.yaml",
+			content:     "sets:\n  - name: users\n    spec: struct{Id}\n",
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		location := path.Join(t.TempDir(), testCase.file)
+		if !assert.Nil(t, os.WriteFile(location, []byte(testCase.content), 0644), testCase.description) {
+			continue
+		}
+		schema, err := LoadSchema(location)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		registry := NewRegistry()
+		err = schema.Register(registry)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for _, name := range testCase.expectSets {
+			assert.True(t, registry.Has(name), testCase.description+" "+name)
+		}
+		assert.Equal(t, testCase.expectTTL, registry.Lookup("users").ttlSec, testCase.description)
+	}
+}
+
+func Test_schemaRegistries(t *testing.T) {
+	location := path.Join(t.TempDir(), "sets.json")
+	if !assert.Nil(t, os.WriteFile(location, []byte(`{"sets": [{"name": "users", "spec": "struct{Id int; Name string}"}]}`), 0644)) {
+		return
+	}
+	dsn := "aerospike://127.0.0.1:3000/test?schema=" + location
+	cfg, err := ParseDSN(dsn)
+	if !assert.Nil(t, err) {
+		return
+	}
+	schemas := &schemaRegistries{registries: map[schemaKey]*schemaRegistry{}, dsn: map[*Registry]string{}}
+	conn := &connection{cfg: cfg}
+	registry, err := schemas.registry(context.Background(), conn, dsn, globalSets)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, registry.Has("users"))
+	assert.Equal(t, globalSets, registry.parent)
+
+	assert.Nil(t, os.Remove(location))
+	pooled, err := schemas.registry(context.Background(), &connection{cfg: cfg}, dsn, globalSets)
+	assert.Nil(t, err, "schema is loaded once per DSN")
+	assert.Equal(t, registry, pooled, "pooled connections share schema registry")
+	same, err := schemas.registry(context.Background(), conn, dsn, registry)
+	assert.Nil(t, err)
+	assert.Equal(t, registry, same, "registry with schema parent")
+
+	_, err = schemas.registry(context.Background(), conn, dsn, NewRegistry())
+	assert.NotNil(t, err, "isolated registry loads its own schema sets")
+}
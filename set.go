@@ -1,7 +1,10 @@
 package aerospike
 
 import (
+	"fmt"
 	"github.com/viant/x"
+	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -12,9 +15,158 @@ type set struct {
 	typeBasedMapper *mapper
 	queryMapper     map[string]*mapper
 	ttlSec          uint32
+	mapOrder        MapOrder
+	mapWriteMode    MapWriteMode
+	listOrder       ListOrder
+	listUnique      bool
+	listBounded     bool
+	mapShards       int
+	keyProvider     KeyProvider
+	binAliasing     bool
+	binAliases      map[string]string
 	mux             sync.RWMutex
 }
 
+// DefinitionError lists every set definition violation detected at registration
+type DefinitionError struct {
+	Set        string
+	Violations []error
+}
+
+// Error returns error message
+func (e *DefinitionError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Error()
+	}
+	return fmt.Sprintf("invalid set %v definition: %v", e.Set, strings.Join(messages, "; "))
+}
+
+// Unwrap returns violations
+func (e *DefinitionError) Unwrap() []error {
+	return e.Violations
+}
+
+// lookupTypeBasedMapper returns set type based mapper, mapper is created on first use
+func (s *set) lookupTypeBasedMapper(recordType reflect.Type) (*mapper, error) {
+	s.mux.RLock()
+	aMapper := s.typeBasedMapper
+	s.mux.RUnlock()
+	if aMapper != nil {
+		return aMapper, nil
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.typeBasedMapper == nil {
+		var err error
+		if s.typeBasedMapper, err = newTypeBasedMapper(recordType); err != nil {
+			return nil, err
+		}
+	}
+	return s.typeBasedMapper, nil
+}
+
+// validate creates set type mapper, it returns DefinitionError listing every set definition violation
+func (s *set) validate() error {
+	if s.xType.Type == nil {
+		return nil
+	}
+	aMapper, violations := mapRecordType(s.xType.Type)
+	if aMapper != nil {
+		violations = append(violations, s.mapperViolations(aMapper)...)
+		violations = append(violations, s.binNameViolations(aMapper)...)
+	}
+	if len(violations) > 0 {
+		return &DefinitionError{Set: s.xType.Name, Violations: violations}
+	}
+	if s.typeBasedMapper == nil {
+		s.typeBasedMapper = aMapper
+	}
+	return nil
+}
+
+// mapperViolations returns collection and index tag violations
+func (s *set) mapperViolations(aMapper *mapper) []error {
+	var violations []error
+	tagged := map[string][]string{}
+	for i := range aMapper.fields {
+		aField := &aMapper.fields[i]
+		if aField.tag.IsSecondaryIndex {
+			tagged["secondaryIndex"] = append(tagged["secondaryIndex"], aField.Name)
+		}
+		if aField.tag.IsArrayIndex {
+			tagged["arrayIndex"] = append(tagged["arrayIndex"], aField.Name)
+		}
+		if aField.tag.IsComponent {
+			tagged["component"] = append(tagged["component"], aField.Name)
+		}
+		if aField.tag.IsGeneration {
+			tagged["generation"] = append(tagged["generation"], aField.Name)
+		}
+		if aField.tag.IsTTL {
+			tagged["ttl"] = append(tagged["ttl"], aField.Name)
+		}
+		if aField.tag.Compress != "" && aField.tag.Codec == "" && (aField.tag.IsDecimal || isNumericKind(baseType(aField.Type).Kind())) {
+			violations = append(violations, fmt.Errorf("unsupported compress tag on numeric field %v", aField.Name))
+		}
+	}
+	for _, kind := range []string{"secondaryIndex", "arrayIndex", "component", "generation", "ttl"} {
+		if fields := tagged[kind]; len(fields) > 1 {
+			violations = append(violations, fmt.Errorf("multiple %v tags on fields %v", kind, strings.Join(fields, ", ")))
+		}
+	}
+	if aMapper.component != nil && aMapper.arrayIndex == nil {
+		violations = append(violations, fmt.Errorf("component tag on field %v requires arrayIndex tag", aMapper.component.Name))
+	}
+	return violations
+}
+
+// isEquivalent returns true if set has the same record type layout and options as candidate
+func (s *set) isEquivalent(candidate *set) bool {
+	if !isEquivalentType(s.xType.Type, candidate.xType.Type) {
+		return false
+	}
+	return s.ttlSec == candidate.ttlSec &&
+		s.mapOrder == candidate.mapOrder &&
+		s.mapWriteMode == candidate.mapWriteMode &&
+		s.listOrder == candidate.listOrder &&
+		s.listUnique == candidate.listUnique &&
+		s.listBounded == candidate.listBounded &&
+		s.mapShards == candidate.mapShards &&
+		s.binAliasing == candidate.binAliasing &&
+		isSameKeyProvider(s.keyProvider, candidate.keyProvider)
+}
+
+// isEquivalentType returns true if struct types have the same fields, types and tags
+func isEquivalentType(rType, candidate reflect.Type) bool {
+	if rType == candidate {
+		return true
+	}
+	if rType == nil || candidate == nil || rType.Kind() != reflect.Struct || candidate.Kind() != reflect.Struct {
+		return false
+	}
+	if rType.NumField() != candidate.NumField() {
+		return false
+	}
+	for i := 0; i < rType.NumField(); i++ {
+		field, candidateField := rType.Field(i), candidate.Field(i)
+		if field.Name != candidateField.Name || field.Type != candidateField.Type || field.Tag != candidateField.Tag {
+			return false
+		}
+	}
+	return true
+}
+
+func isSameKeyProvider(provider, candidate KeyProvider) bool {
+	if provider == nil || candidate == nil {
+		return provider == candidate
+	}
+	if reflect.TypeOf(provider) != reflect.TypeOf(candidate) || !reflect.TypeOf(provider).Comparable() {
+		return false
+	}
+	return provider == candidate
+}
+
 func (s *set) lookupQueryMapper(query string) *mapper {
 	if len(s.queryMapper) == 0 {
 		return nil
@@ -33,9 +185,8 @@ func (s *set) registerQueryMapper(query string, aMapper *mapper) {
 	s.queryMapper[query] = aMapper
 }
 
-
 func WithTTLSec(ttlSec uint32) Option {
 	return func(s *set) {
 		s.ttlSec = ttlSec
 	}
-}
\ No newline at end of file
+}
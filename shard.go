@@ -0,0 +1,209 @@
+package aerospike
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	as "github.com/aerospike/aerospike-client-go/v6"
+)
+
+// WithMapShards distributes collection map entries of each pk across n sub-records by map key hash,
+// changing shard count of a set with existing data requires reloading the data
+func WithMapShards(n int) Option {
+	return func(s *set) {
+		s.mapShards = n
+	}
+}
+
+// isSharded returns true if collection map entries are distributed across sub-records
+func (s *set) isSharded() bool {
+	return s.mapShards > 1
+}
+
+// mapShard returns index of the sub-record holding map key entry
+func (s *set) mapShard(mapKey interface{}) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(fmt.Sprintf("%v", mapKey)))
+	return int(hash.Sum32() % uint32(s.mapShards))
+}
+
+// shardKeyValue returns record key value of the sub-record holding map key entry
+func (s *set) shardKeyValue(pkValue interface{}, mapKey interface{}) interface{} {
+	if !s.isSharded() {
+		return pkValue
+	}
+	return shardKeyValue(pkValue, s.mapShard(mapKey))
+}
+
+func shardKeyValue(pkValue interface{}, shard int) string {
+	return fmt.Sprintf("%v#%d", pkValue, shard)
+}
+
+// shardGroups splits map entries group by sub-record key value
+func (s *set) shardGroups(pkValue interface{}, group map[interface{}]map[interface{}]interface{}) map[interface{}]map[interface{}]map[interface{}]interface{} {
+	if !s.isSharded() {
+		return map[interface{}]map[interface{}]map[interface{}]interface{}{pkValue: group}
+	}
+	var result = make(map[interface{}]map[interface{}]map[interface{}]interface{})
+	for mapKey, entry := range group {
+		keyValue := s.shardKeyValue(pkValue, mapKey)
+		shard, ok := result[keyValue]
+		if !ok {
+			shard = make(map[interface{}]map[interface{}]interface{})
+			result[keyValue] = shard
+		}
+		shard[mapKey] = entry
+	}
+	return result
+}
+
+// shardPkOperations returns operations storing logical pk in sharded sub-record, so that set scan can restore it
+func (s *Statement) shardPkOperations(aSet *set, pkValue interface{}) []*as.Operation {
+	if !aSet.isSharded() || len(s.mapper.pk) == 0 {
+		return nil
+	}
+	return []*as.Operation{as.PutOp(as.NewBin(s.mapper.pk[0].Column(), pkValue))}
+}
+
+// shardKeys returns keys of all sub-records of the pk key
+func (s *Statement) shardKeys(aSet *set, key *as.Key) ([]*as.Key, error) {
+	if !aSet.isSharded() {
+		return []*as.Key{key}, nil
+	}
+	pkValue := key.Value().GetObject()
+	var result = make([]*as.Key, 0, aSet.mapShards)
+	for i := 0; i < aSet.mapShards; i++ {
+		shardKey, err := as.NewKey(s.namespace, s.set, shardKeyValue(pkValue, i))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, shardKey)
+	}
+	return result, nil
+}
+
+// operateMap runs map bin operations on the pk record or on all its sub-records, merging results as one logical map
+func (s *Statement) operateMap(ctx context.Context, aSet *set, writePolicy *as.WritePolicy, key *as.Key, ops []*as.Operation) (*as.Record, error) {
+	if !aSet.isSharded() {
+		return s.operateWithCtx(ctx, writePolicy, key, ops)
+	}
+	keys, err := s.shardKeys(aSet, key)
+	if err != nil {
+		return nil, err
+	}
+	var records = make([]*as.Record, 0, len(keys))
+	var notFound error
+	for _, shardKey := range keys {
+		record, opErr := s.operateWithCtx(ctx, writePolicy, shardKey, ops)
+		if opErr != nil {
+			if IsKeyNotFound(opErr) {
+				notFound = opErr
+				continue
+			}
+			return nil, opErr
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil, notFound
+	}
+	return s.mergeShardRecords(key, records), nil
+}
+
+// getMap reads pk record or all its sub-records, merging map bin as one logical map
+func (s *Statement) getMap(ctx context.Context, aSet *set, key *as.Key, binNames []string) (*as.Record, error) {
+	if !aSet.isSharded() {
+		return s.getWithCtx(ctx, nil, key, binNames)
+	}
+	keys, err := s.shardKeys(aSet, key)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.batchGetWithCtx(ctx, nil, keys, binNames)
+	if err != nil && !IsKeyNotFound(err) {
+		return nil, err
+	}
+	var found []*as.Record
+	for _, record := range records {
+		if record != nil {
+			found = append(found, record)
+		}
+	}
+	if len(found) == 0 {
+		return nil, as.ErrKeyNotFound
+	}
+	return s.mergeShardRecords(key, found), nil
+}
+
+// getMaps reads logical map records of supplied pk keys, missing records are returned as nil
+func (s *Statement) getMaps(ctx context.Context, aSet *set, keys []*as.Key) ([]*as.Record, error) {
+	var result = make([]*as.Record, len(keys))
+	for i, key := range keys {
+		record, err := s.getMap(ctx, aSet, key, nil)
+		if err != nil {
+			if IsKeyNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		result[i] = record
+	}
+	return result, nil
+}
+
+// mergeShardRecords merges sub-records collection map bin results into logical pk record
+func (s *Statement) mergeShardRecords(key *as.Key, records []*as.Record) *as.Record {
+	result := &as.Record{Key: key, Bins: as.BinMap{}}
+	for _, record := range records {
+		if record.Generation > result.Generation {
+			result.Generation = record.Generation
+		}
+		if record.Expiration > result.Expiration {
+			result.Expiration = record.Expiration
+		}
+		value, ok := record.Bins[s.collectionBin]
+		if !ok {
+			continue
+		}
+		switch actual := value.(type) {
+		case map[interface{}]interface{}:
+			merged, _ := result.Bins[s.collectionBin].(map[interface{}]interface{})
+			if merged == nil {
+				merged = make(map[interface{}]interface{}, len(actual))
+			}
+			for k, v := range actual {
+				merged[k] = v
+			}
+			result.Bins[s.collectionBin] = merged
+		case []as.MapPair:
+			merged, _ := result.Bins[s.collectionBin].([]as.MapPair)
+			result.Bins[s.collectionBin] = append(merged, actual...)
+		case []interface{}:
+			merged, _ := result.Bins[s.collectionBin].([]interface{})
+			result.Bins[s.collectionBin] = append(merged, actual...)
+		default:
+			if value != nil {
+				result.Bins[s.collectionBin] = value
+			}
+		}
+	}
+	return result
+}
+
+// restoreShardKey replaces scanned sub-record key with logical pk key
+func (s *Statement) restoreShardKey(aSet *set, record *as.Record) error {
+	if !aSet.isSharded() || len(s.mapper.pk) == 0 {
+		return nil
+	}
+	pkValue, ok := record.Bins[s.mapper.pk[0].Column()]
+	if !ok || pkValue == nil {
+		return fmt.Errorf("unable to restore %v pk of sharded record", s.set)
+	}
+	key, err := as.NewKey(s.namespace, s.set, pkValue)
+	if err != nil {
+		return err
+	}
+	record.Key = key
+	return nil
+}
@@ -0,0 +1,52 @@
+package aerospike
+
+import (
+	as "github.com/aerospike/aerospike-client-go/v6"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_shardGroups(t *testing.T) {
+	group := map[interface{}]map[interface{}]interface{}{}
+	for i := 0; i < 100; i++ {
+		group[i] = map[interface{}]interface{}{"score": i}
+	}
+	unsharded := (&set{}).shardGroups("b1", group)
+	assert.Equal(t, 1, len(unsharded))
+	assert.Equal(t, 100, len(unsharded["b1"]))
+
+	aSet := &set{mapShards: 4}
+	sharded := aSet.shardGroups("b1", group)
+	assert.Equal(t, 4, len(sharded))
+	count := 0
+	for keyValue, shard := range sharded {
+		for mapKey := range shard {
+			assert.Equal(t, keyValue, aSet.shardKeyValue("b1", mapKey))
+			count++
+		}
+	}
+	assert.Equal(t, 100, count)
+	assert.Equal(t, aSet.mapShard("p1"), aSet.mapShard("p1"))
+}
+
+func Test_mergeShardRecords(t *testing.T) {
+	key, err := as.NewKey("test", "Leaderboard", "b1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	stmt := &Statement{collectionBin: "scores"}
+	merged := stmt.mergeShardRecords(key, []*as.Record{
+		{Bins: as.BinMap{"scores": map[interface{}]interface{}{"p1": 10}}, Generation: 2},
+		{Bins: as.BinMap{"scores": map[interface{}]interface{}{"p2": 20}}, Generation: 5},
+	})
+	assert.Equal(t, key, merged.Key)
+	assert.EqualValues(t, 5, merged.Generation)
+	assert.Equal(t, map[interface{}]interface{}{"p1": 10, "p2": 20}, merged.Bins["scores"])
+
+	merged = stmt.mergeShardRecords(key, []*as.Record{
+		{Bins: as.BinMap{"scores": []as.MapPair{{Key: "p1", Value: 10}}}},
+		{Bins: as.BinMap{}},
+		{Bins: as.BinMap{"scores": []as.MapPair{{Key: "p2", Value: 20}}}},
+	})
+	assert.Equal(t, []as.MapPair{{Key: "p1", Value: 10}, {Key: "p2", Value: 20}}, merged.Bins["scores"])
+}
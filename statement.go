@@ -20,6 +20,7 @@ import (
 	"github.com/viant/xreflect"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 type collectionType string
@@ -44,7 +45,7 @@ type Statement struct {
 	//BaseURL    string
 	SQL              string
 	kind             sqlparser.Kind
-	sets             *registry
+	sets             *Registry
 	query            *query.Select
 	insert           *ainsert.Statement
 	update           *update.Statement
@@ -52,8 +53,12 @@ type Statement struct {
 	truncate         *table.Truncate
 	createIndex      *index.Create
 	dropIndex        *index.Drop
+	unregister       *unregisterSet
+	sampleSize       int
 	mapper           *mapper
+	mappedSet        *set
 	filter           *as.Filter
+	filterExpression *as.Expression
 	mapRangeFilter   *rangeBinFilter
 	arrayRangeFilter *rangeBinFilter
 	recordType       reflect.Type
@@ -71,9 +76,14 @@ type Statement struct {
 	mapKeyValues         []interface{}
 	arrayIndexValues     []int
 	secondaryIndexValues []interface{}
+	generation           *uint32
 	lastInsertID         *int64
 	affected             int64
 	writeLimiter         *limiter
+	valueRange           *valueRange
+	returning            []string
+	returned             []*as.Record
+	returnedMux          sync.Mutex
 }
 
 // Exec executes statements
@@ -87,6 +97,15 @@ func (s *Statement) ExecContext(ctx context.Context, args []driver.NamedValue) (
 	switch s.kind {
 	case sqlparser.KindRegisterSet:
 		return s.handleRegisterSet(args)
+	case kindUnregisterSet:
+		return s.handleUnregisterSet()
+	case kindAnalyzeSet:
+		return nil, fmt.Errorf("unsupported exec of %v statement, use query", s.kind)
+	}
+	if err := s.refreshTypeBasedMapper(ctx); err != nil {
+		return nil, err
+	}
+	switch s.kind {
 	case sqlparser.KindInsert:
 		if err := s.handleInsert(ctx, args); err != nil {
 			return nil, err
@@ -96,7 +115,7 @@ func (s *Statement) ExecContext(ctx context.Context, args []driver.NamedValue) (
 			return nil, err
 		}
 	case sqlparser.KindDelete:
-		return nil, s.handleDelete(ctx)
+		return s.handleDelete(ctx, args)
 	case sqlparser.KindSelect:
 		return nil, fmt.Errorf("unsupported parameterizedQuery type: %v", s.kind)
 	case sqlparser.KindDropIndex:
@@ -122,10 +141,18 @@ func (s *Statement) Query(args []driver.Value) (driver.Rows, error) {
 // QueryContext runs parameterizedQuery
 func (s *Statement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
 	switch s.kind {
-	case sqlparser.KindSelect:
+	case sqlparser.KindSelect, sqlparser.KindInsert, sqlparser.KindUpdate:
+	case kindAnalyzeSet:
+		return s.handleAnalyzeSet(ctx)
 	default:
 		return nil, fmt.Errorf("unsupported parameterizedQuery type: %v", s.kind)
 	}
+	if err := s.refreshTypeBasedMapper(ctx); err != nil {
+		return nil, err
+	}
+	if s.kind != sqlparser.KindSelect {
+		return s.executeReturning(ctx, args)
+	}
 	return s.executeSelect(ctx, args)
 }
 
@@ -183,40 +210,60 @@ func checkQueryParameters(query string) int {
 	return count
 }
 
+// loadSpecType returns record type of struct spec, i.e. struct{Id int; Name string}
+func loadSpecType(name, spec string) (reflect.Type, error) {
+	aType := xreflect.NewType(name, xreflect.WithTypeDefinition(spec))
+	rType, err := aType.LoadType(xreflect.NewTypes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to register set %s and spec %s due to: %w", name, spec, err)
+	}
+	return rType, nil
+}
+
 // TODO
 func (s *Statement) handleRegisterSet(args []driver.NamedValue) (driver.Result, error) {
-	register, err := sqlparser.ParseRegisterSet(s.SQL)
+	replace := isReplaceSet(s.SQL)
+	SQL, options, err := extractCollectionOptions(asRegisterSet(s.SQL))
+	if err != nil {
+		return nil, err
+	}
+	register, err := sqlparser.ParseRegisterSet(SQL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse set definition: %s due to: %w", s.SQL, err)
 	}
 	spec := strings.TrimSpace(register.Spec)
 	var rType reflect.Type
 	if spec == "?" {
+		if len(args) == 0 || args[0].Value == nil {
+			return nil, fmt.Errorf("unable to register set %s: missing record type parameter", register.Name)
+		}
 		rType = reflect.TypeOf(args[0].Value)
 		if rType.Kind() == reflect.Ptr {
 			rType = rType.Elem()
 		}
-	} else {
-		aType := xreflect.NewType(register.Name, xreflect.WithTypeDefinition(spec))
-		if rType, err = aType.LoadType(xreflect.NewTypes()); err != nil {
-			return nil, fmt.Errorf("unable to register set %s and spec %s due to: %w", register.Name, spec, err)
-		}
+	} else if rType, err = loadSpecType(register.Name, spec); err != nil {
+		return nil, err
 	}
 	aSet := &set{
 		xType:  x.NewType(rType, x.WithName(register.Name)),
 		ttlSec: register.TTL, //TODO use TTL with WritePolicy
 	}
+	for _, option := range options {
+		option(aSet)
+	}
+	// global sets are registered only in the global registry, connection registries look them up through their parent
+	sets := s.sets
 	if register.Global {
-		if err := registerSet(aSet); err != nil {
-			return nil, err
-		}
+		sets = globalSets
+	}
+	if replace {
+		err = sets.replace(aSet)
+	} else {
+		err = sets.Register(aSet)
 	}
-
-	err = s.sets.Register(aSet)
 	if err != nil {
 		return nil, err
 	}
-
 	return &result{}, nil
 }
 
@@ -248,6 +295,8 @@ func (s *Statement) setRecordType(aSet *set) error {
 }
 
 func (s *Statement) updateCriteria(qualify *expr.Qualify, args []driver.NamedValue, includeFilter bool) error {
+	s.filterExpression = nil
+	s.valueRange = nil
 	if qualify == nil {
 		return nil
 	}
@@ -273,6 +322,11 @@ func (s *Statement) updateCriteria(qualify *expr.Qualify, args []driver.NamedVal
 	if s.mapper != nil && s.mapper.secondaryIndex != nil {
 		indexName = strings.ToLower(s.mapper.secondaryIndex.Column())
 	}
+
+	generationName := "-----"
+	if s.mapper != nil && s.mapper.generation != nil {
+		generationName = strings.ToLower(s.mapper.generation.Column())
+	}
 	isMultiInPk := len(s.mapper.pk) > 1
 	isMultiInKey := len(s.mapper.mapKey) > 1
 	isSecondaryIndexKey := s.mapper.secondaryIndex != nil
@@ -292,15 +346,18 @@ func (s *Statement) updateCriteria(qualify *expr.Qualify, args []driver.NamedVal
 		}
 	}
 	idx := 0
+	var filterExpressions []*as.Expression
 	err := binary.Walk(func(ident node.Node, values *expr.Values, operator, parentOperator string) error {
 		if parentOperator != "" && strings.ToUpper(parentOperator) != "AND" {
 			return fmt.Errorf("unuspported logical operator: %s", parentOperator)
 		}
 		values.Idx = idx
+		path := s.mapper.lookupPath(sqlparser.Stringify(ident))
 		name := strings.ToLower(sqlparser.Stringify(ident))
 		if idx := strings.Index(name, "."); idx != -1 {
 			name = name[idx+1:]
 		}
+		name = strings.Trim(name, "`")
 		var exprValues = values.Values(func(idx int) interface{} {
 			return args[idx].Value
 		})
@@ -317,6 +374,48 @@ func (s *Statement) updateCriteria(qualify *expr.Qualify, args []driver.NamedVal
 			}
 		}
 		idx = values.Idx
+		if op := strings.ToUpper(operator); op == "IS" || op == "IS NOT" {
+			for _, value := range exprValues {
+				if value != nil {
+					return fmt.Errorf("unsupported %v criteria value: %v", op, value)
+				}
+			}
+			expression, err := nullExpression(s.mapper.getField(name), path, op == "IS")
+			if err != nil {
+				return err
+			}
+			filterExpressions = append(filterExpressions, expression)
+			return nil
+		}
+		if path != nil {
+			expression, err := pathExpression(path, operator, exprValues)
+			if err != nil {
+				return err
+			}
+			filterExpressions = append(filterExpressions, expression)
+			return nil
+		}
+		if aField := s.mapper.getField(name); aField != nil {
+			if aField.tag.IsEncrypted {
+				return fmt.Errorf("unsupported criteria on encrypted column: %s", name)
+			}
+			if aField.tag.IsDecimal {
+				for i := range exprValues {
+					var err error
+					if exprValues[i], err = aField.ensureValueType(exprValues[i]); err != nil {
+						return err
+					}
+				}
+			}
+			if aField.tag.hasTimeEncoding() && isTimeType(aField.Type) {
+				for i := range exprValues {
+					var err error
+					if exprValues[i], err = aField.tag.encodeTimeValue(exprValues[i]); err != nil {
+						return err
+					}
+				}
+			}
+		}
 		//TODO add support for multi in (col1,col2) IN((?, ?), (?, ?))
 		if isMultiInPk {
 
@@ -331,11 +430,23 @@ func (s *Statement) updateCriteria(qualify *expr.Qualify, args []driver.NamedVal
 				return nil
 			}
 		}
+		if includeFilter && s.isValueColumn(name) {
+			return s.updateValueRange(operator, exprValues)
+		}
 		switch name {
 		case indexName:
 			s.secondaryIndexValues = exprValues
 		case "pk", pkName:
 			s.pkValues = exprValues
+		case generationColumn, generationName:
+			if operator != "=" || len(exprValues) != 1 {
+				return fmt.Errorf("unsupported operator of a generation criteria: %s", operator)
+			}
+			generation, err := asGeneration(exprValues[0])
+			if err != nil {
+				return err
+			}
+			s.generation = &generation
 		case arrayIndex, "index":
 			switch strings.ToLower(operator) {
 			case "=", "in":
@@ -402,6 +513,31 @@ func (s *Statement) updateCriteria(qualify *expr.Qualify, args []driver.NamedVal
 	if err != nil {
 		return err
 	}
+	switch len(filterExpressions) {
+	case 0:
+	case 1:
+		s.filterExpression = filterExpressions[0]
+	default:
+		s.filterExpression = as.ExpAnd(filterExpressions...)
+	}
+	return nil
+}
+
+func (s *Statement) updateValueRange(operator string, exprValues []interface{}) error {
+	switch strings.ToLower(operator) {
+	case "=":
+		if len(exprValues) != 1 {
+			return fmt.Errorf("invalid criteria values")
+		}
+		s.valueRange = &valueRange{begin: exprValues[0], end: exprValues[0]}
+	case "between":
+		if len(exprValues) != 2 {
+			return fmt.Errorf("invalid criteria - between expects 2 values")
+		}
+		s.valueRange = &valueRange{begin: exprValues[0], end: exprValues[1]}
+	default:
+		return fmt.Errorf("unsupported operator of a collection value: %s", operator)
+	}
 	return nil
 }
 
@@ -446,12 +582,15 @@ func (s *Statement) buildKeys() ([]*as.Key, error) {
 	return result, nil
 }
 
-func (s *Statement) setTypeBasedMapper() error {
+func (s *Statement) setTypeBasedMapper(ctx context.Context) error {
 	var err error
 	if s.set == "" {
 		return nil
 	}
 	aSet, err := s.lookupSet()
+	if err != nil && s.canInferSet() {
+		aSet, err = s.inferSet(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("unable to lookup set with name %s, %w", s.set, err)
 	}
@@ -461,13 +600,14 @@ func (s *Statement) setTypeBasedMapper() error {
 	}
 
 	s.record = reflect.New(s.recordType).Interface()
-	if aSet.typeBasedMapper == nil {
-		if s.mapper, err = newTypeBasedMapper(s.recordType); err != nil {
+	s.mappedSet = aSet
+	if s.mapper, err = aSet.lookupTypeBasedMapper(s.recordType); err != nil {
+		return err
+	}
+	if aSet.binAliasing && s.collectionBin == "" {
+		if s.mapper, err = s.loadBinAliases(ctx, aSet); err != nil {
 			return err
 		}
-		aSet.typeBasedMapper = s.mapper
-	} else {
-		s.mapper = aSet.typeBasedMapper
 	}
 
 	if len(s.mapper.mapKey) > 0 {
@@ -475,19 +615,97 @@ func (s *Statement) setTypeBasedMapper() error {
 	} else if s.mapper.arrayIndex != nil {
 		s.collectionType = collectionTypeArray
 	}
+	return s.checkCollectionBin()
+}
 
-	return nil
+// checkCollectionBin returns error if set with mapKey or arrayIndex tag is queried or updated without /bin suffix,
+// inserts and deletes without collection bin operate on whole records
+func (s *Statement) checkCollectionBin() error {
+	if s.collectionBin != "" || s.collectionType == "" {
+		return nil
+	}
+	if s.kind != sqlparser.KindSelect && s.kind != sqlparser.KindUpdate {
+		return nil
+	}
+	aField, tag := s.mapper.arrayIndex, "arrayIndex"
+	if len(s.mapper.mapKey) > 0 {
+		aField, tag = s.mapper.mapKey[0], "mapKey"
+	}
+	return fmt.Errorf("unable to query set %v with %v tag on field %v without /bin suffix, e.g. %v/values", s.set, tag, aField.Name, s.set)
+}
+
+// refreshTypeBasedMapper rebuilds statement mapper if its set was replaced or unregistered after statement was prepared
+func (s *Statement) refreshTypeBasedMapper(ctx context.Context) error {
+	if s.mappedSet == nil {
+		return nil
+	}
+	if aSet, _ := s.lookupSet(); aSet == s.mappedSet {
+		return nil
+	}
+	return s.setTypeBasedMapper(ctx)
 }
 
-func (s *Statement) handleDelete(ctx context.Context) error {
+func (s *Statement) handleDelete(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
 	if s.delete.Qualify == nil {
-		return s.truncateWithCtx(ctx, nil, s.namespace, s.set, nil)
+		if err := s.truncateWithCtx(ctx, nil, s.namespace, s.set, nil); err != nil {
+			return nil, err
+		}
+		return &result{}, s.truncateChunks(ctx)
 	}
 	if isDryRun("delete") {
-		return nil
+		return &result{}, nil
+	}
+	s.pkValues = nil
+	s.generation = nil
+	if err := s.updateCriteria(s.delete.Qualify, args, false); err != nil {
+		return nil, err
 	}
+	if len(s.pkValues) == 0 || s.collectionBin != "" || len(s.secondaryIndexValues) > 0 {
+		return nil, fmt.Errorf("not yet supported")
+	}
+	return s.handleDeleteByKeys(ctx)
+}
 
-	return fmt.Errorf("not yet supported")
+// handleDeleteByKeys deletes records by pk criteria together with their companion chunk records
+func (s *Statement) handleDeleteByKeys(ctx context.Context) (driver.Result, error) {
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.buildKeys()
+	if err != nil {
+		return nil, err
+	}
+	writePolicy := s.writePolicy(aSet, false)
+	writePolicy.FilterExpression = s.filterExpression
+	if s.generation != nil {
+		expectGeneration(writePolicy, *s.generation)
+	}
+	ret := &result{}
+	for _, key := range keys {
+		chunks, err := s.chunkDescriptors(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		existed, err := s.deleteWithCtx(ctx, writePolicy, key)
+		if err != nil {
+			if hasResultCode(err, types.FILTERED_OUT) {
+				continue
+			}
+			if s.generation != nil {
+				return nil, generationError(err, key, *s.generation)
+			}
+			return nil, err
+		}
+		if !existed {
+			continue
+		}
+		ret.totalRows++
+		if err = s.deleteChunks(ctx, key, chunks); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
 }
 
 func (s *Statement) getKey(fields []*field, bins map[string]interface{}) interface{} {
@@ -529,8 +747,20 @@ func (s *Statement) cleanup() {
 	s.mapper = nil
 }
 
+// ErrGenerationMismatch is returned when a write expects a record generation that does not match the stored one.
+var ErrGenerationMismatch = errors.New("record generation mismatch")
+
 // IsKeyNotFound returns true if mapKey not found error.
 func IsKeyNotFound(err error) bool {
+	return hasResultCode(err, types.KEY_NOT_FOUND_ERROR)
+}
+
+// IsGenerationMismatch returns true if generation mismatch error.
+func IsGenerationMismatch(err error) bool {
+	return errors.Is(err, ErrGenerationMismatch) || hasResultCode(err, types.GENERATION_ERROR)
+}
+
+func hasResultCode(err error, code types.ResultCode) bool {
 	if err == nil {
 		return false
 	}
@@ -546,7 +776,15 @@ func IsKeyNotFound(err error) bool {
 		}
 
 	}
-	return aeroError.ResultCode == types.KEY_NOT_FOUND_ERROR
+	return aeroError.ResultCode == code
+}
+
+// generationError wraps aerospike generation error with ErrGenerationMismatch
+func generationError(err error, key *as.Key, generation uint32) error {
+	if hasResultCode(err, types.GENERATION_ERROR) {
+		return fmt.Errorf("%w: key %v, expected generation %v: %v", ErrGenerationMismatch, key.Value(), generation, err)
+	}
+	return err
 }
 
 type rangeBinFilter struct {
@@ -563,3 +801,9 @@ func (s *Statement) writePolicy(aSet *set, sendKey bool) *as.WritePolicy {
 	writePolicy.MaxRetries = 0
 	return &writePolicy
 }
+
+// expectGeneration restricts write to records with matching generation
+func expectGeneration(writePolicy *as.WritePolicy, generation uint32) {
+	writePolicy.GenerationPolicy = as.EXPECT_GEN_EQUAL
+	writePolicy.Generation = generation
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/viant/tagly/tags"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Tag struct {
@@ -15,8 +16,25 @@ type Tag struct {
 	IsArrayIndex     bool
 	Ignore           bool
 	UnixSec          bool
+	UnixMs           bool
+	UnixNano         bool
+	TimeFormat       string
+	TimeZone         *time.Location
 	ArraySize        int
 	IsComponent      bool
+	IsGeneration     bool
+	IsTTL            bool
+	IsHLL            bool
+	HLLIndexBits     int
+	IsChunked        bool
+	ChunkSize        int
+	Compress         string
+	IsEncrypted      bool
+	Codec            string
+	OmitEmpty        bool
+	IsNullable       bool
+	Scale            int
+	IsDecimal        bool
 }
 
 func (t *Tag) updateTagKey(key, value string) error {
@@ -63,6 +81,90 @@ func (t *Tag) updateTagKey(key, value string) error {
 		} else if t.IsMapKey, err = strconv.ParseBool(value); err != nil {
 			return err
 		}
+	case "generation":
+		if value == "" {
+			t.IsGeneration = true
+		} else if t.IsGeneration, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "ttl":
+		if value == "" {
+			t.IsTTL = true
+		} else if t.IsTTL, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "hll":
+		if value == "" {
+			t.IsHLL = true
+		} else if t.IsHLL, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "hllbits":
+		if t.HLLIndexBits, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return err
+		}
+		t.IsHLL = true
+	case "chunked":
+		t.IsChunked = true
+		if value != "" {
+			if t.ChunkSize, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return err
+			}
+		}
+	case "compress":
+		t.Compress = strings.ToLower(strings.TrimSpace(value))
+		if _, err = compressionCodecID(t.Compress); err != nil {
+			return err
+		}
+	case "scale":
+		if t.Scale, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return err
+		}
+		if t.Scale < 0 || t.Scale > maxDecimalScale {
+			return fmt.Errorf("invalid scale: %v, expected 0-%v", t.Scale, maxDecimalScale)
+		}
+		t.IsDecimal = true
+	case "omitempty":
+		if value == "" {
+			t.OmitEmpty = true
+		} else if t.OmitEmpty, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "nullable":
+		if value == "" {
+			t.IsNullable = true
+		} else if t.IsNullable, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "codec":
+		t.Codec = strings.ToLower(strings.TrimSpace(value))
+		if _, err = lookupCodec(t.Codec); err != nil {
+			return err
+		}
+	case "encrypt":
+		if value == "" {
+			t.IsEncrypted = true
+		} else if t.IsEncrypted, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "unixms":
+		if value == "" {
+			t.UnixMs = true
+		} else if t.UnixMs, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "unixnano":
+		if value == "" {
+			t.UnixNano = true
+		} else if t.UnixNano, err = strconv.ParseBool(value); err != nil {
+			return err
+		}
+	case "format":
+		t.TimeFormat = strings.Trim(value, "'")
+	case "tz":
+		if t.TimeZone, err = time.LoadLocation(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid tz %v: %w", value, err)
+		}
 	case "unixsec":
 		if value == "" {
 			t.UnixSec = true
@@ -84,6 +186,8 @@ func ParseTag(tagString string) (*Tag, error) {
 	} else {
 		tag.Name = name
 	}
-	err := values.MatchPairs(tag.updateTagKey)
-	return tag, err
+	if err := values.MatchPairs(tag.updateTagKey); err != nil {
+		return tag, err
+	}
+	return tag, tag.validateTimeEncoding()
 }
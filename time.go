@@ -0,0 +1,158 @@
+package aerospike
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// isTimeType returns true for time.Time and pointers to it
+func isTimeType(rType reflect.Type) bool {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType == timeType
+}
+
+// hasTimeEncoding returns true if time value is stored as epoch number or formatted string, or read in a time zone
+func (t *Tag) hasTimeEncoding() bool {
+	return t.UnixSec || t.UnixMs || t.UnixNano || t.TimeFormat != "" || t.TimeZone != nil
+}
+
+// validateTimeEncoding checks that at most one time storage option is used
+func (t *Tag) validateTimeEncoding() error {
+	count := 0
+	for _, flag := range []bool{t.UnixSec, t.UnixMs, t.UnixNano, t.TimeFormat != ""} {
+		if flag {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("invalid tag %v: unixsec, unixms, unixnano and format are mutually exclusive", t.Name)
+	}
+	return nil
+}
+
+// encodeTime converts time to bin value, times are normalized to UTC without tz option, so that formatted values
+// are parsed back to the same instant
+func (t *Tag) encodeTime(ts time.Time) interface{} {
+	if t.TimeZone != nil {
+		ts = ts.In(t.TimeZone)
+	} else {
+		ts = ts.UTC()
+	}
+	switch {
+	case t.UnixSec:
+		return ts.Unix()
+	case t.UnixMs:
+		return ts.UnixMilli()
+	case t.UnixNano:
+		return ts.UnixNano()
+	case t.TimeFormat != "":
+		return ts.Format(t.TimeFormat)
+	}
+	return ts
+}
+
+// encodeTimeValue converts time, time pointer or time text value to bin value, other values are returned as is
+func (t *Tag) encodeTimeValue(value interface{}) (interface{}, error) {
+	switch actual := value.(type) {
+	case time.Time:
+		return t.encodeTime(actual), nil
+	case *time.Time:
+		if actual == nil {
+			return nil, nil
+		}
+		return t.encodeTime(*actual), nil
+	case **time.Time:
+		if actual == nil || *actual == nil {
+			return nil, nil
+		}
+		return t.encodeTime(**actual), nil
+	case string:
+		ts, err := t.parseTime(actual)
+		if err != nil {
+			return nil, err
+		}
+		return t.encodeTime(ts), nil
+	case *string:
+		if actual == nil {
+			return nil, nil
+		}
+		return t.encodeTimeValue(*actual)
+	}
+	return value, nil
+}
+
+// parseTime parses time text with format layout or RFC3339
+func (t *Tag) parseTime(text string) (time.Time, error) {
+	location := t.TimeZone
+	if location == nil {
+		location = time.UTC
+	}
+	if t.TimeFormat != "" {
+		if ts, err := time.ParseInLocation(t.TimeFormat, text, location); err == nil {
+			return ts, nil
+		}
+	}
+	ts, err := time.ParseInLocation(time.RFC3339, text, location)
+	if err != nil {
+		return ts, fmt.Errorf("unable to ensure valid value type due to: %w", err)
+	}
+	return ts, nil
+}
+
+// decodeTime converts bin value to time, epoch and text values without tz option are decoded in UTC
+func (t *Tag) decodeTime(value interface{}) (time.Time, error) {
+	var ts time.Time
+	switch actual := value.(type) {
+	case time.Time:
+		ts = actual
+	case string:
+		var err error
+		if ts, err = t.parseTime(actual); err != nil {
+			return ts, err
+		}
+	default:
+		v, err := coerceNumber(value, int64Type)
+		if err != nil {
+			return ts, fmt.Errorf("unable to decode time %v: %w", t.Name, err)
+		}
+		epoch := v.(int64)
+		switch {
+		case t.UnixMs:
+			ts = time.UnixMilli(epoch)
+		case t.UnixNano:
+			ts = time.Unix(0, epoch)
+		default:
+			ts = time.Unix(epoch, 0)
+		}
+	}
+	if t.TimeZone != nil {
+		ts = ts.In(t.TimeZone)
+	} else if _, ok := value.(time.Time); !ok {
+		ts = ts.UTC()
+	}
+	return ts, nil
+}
+
+// setTime sets time or time pointer field with decoded bin value
+func (f *field) setTime(ptr unsafe.Pointer, value interface{}) (time.Time, error) {
+	ts, err := f.tag.decodeTime(value)
+	if err != nil {
+		return ts, err
+	}
+	switch f.Type {
+	case timeType:
+		f.SetValue(ptr, ts)
+	case timePtrType:
+		f.SetValue(ptr, &ts)
+	case timeDoublePtrType:
+		tsPtr := &ts
+		f.SetValue(ptr, &tsPtr)
+	default:
+		return ts, fmt.Errorf("unsupported time field type: %v", f.Type)
+	}
+	return ts, nil
+}
@@ -0,0 +1,69 @@
+package aerospike
+
+import (
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_timeEncoding(t *testing.T) {
+	type Record struct {
+		Id        int        `aerospike:"id,pk=true"`
+		Sec       time.Time  `aerospike:"sec,unixsec"`
+		Ms        time.Time  `aerospike:"ms,unixms"`
+		Nano      *time.Time `aerospike:"nano,unixnano"`
+		Formatted time.Time  `aerospike:"formatted,format=2006-01-02 15:04:05,tz=America/New_York"`
+		Zoned     time.Time  `aerospike:"zoned,unixms,tz=Europe/Warsaw"`
+		Local     time.Time  `aerospike:"local,format=2006-01-02 15:04:05"`
+	}
+	aMapper, err := newTypeBasedMapper(reflect.TypeOf(Record{}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	ts := time.Date(2024, 3, 10, 12, 30, 15, 500000000, time.UTC)
+	newYork, _ := time.LoadLocation("America/New_York")
+	warsaw, _ := time.LoadLocation("Europe/Warsaw")
+	var testCases = []struct {
+		description string
+		column      string
+		value       interface{}
+		expectBin   interface{}
+		expect      time.Time
+	}{
+		{description: "unix seconds", column: "sec", value: ts, expectBin: ts.Unix(), expect: ts.Truncate(time.Second)},
+		{description: "unix milliseconds", column: "ms", value: ts, expectBin: ts.UnixMilli(), expect: ts},
+		{description: "unix milliseconds from text", column: "ms", value: "2024-03-10T12:30:15Z", expectBin: ts.Truncate(time.Second).UnixMilli(), expect: ts.Truncate(time.Second)},
+		{description: "unix nanoseconds pointer", column: "nano", value: &ts, expectBin: ts.UnixNano(), expect: ts},
+		{description: "format with tz", column: "formatted", value: ts, expectBin: "2024-03-10 08:30:15", expect: ts.Truncate(time.Second).In(newYork)},
+		{description: "format text input", column: "formatted", value: "2024-03-10 08:30:15", expectBin: "2024-03-10 08:30:15", expect: ts.Truncate(time.Second).In(newYork)},
+		{description: "epoch with tz", column: "zoned", value: ts, expectBin: ts.UnixMilli(), expect: ts.In(warsaw)},
+		{description: "non UTC time format without tz", column: "local", value: ts.In(warsaw), expectBin: "2024-03-10 12:30:15", expect: ts.Truncate(time.Second)},
+	}
+	for _, testCase := range testCases {
+		aField := aMapper.getField(testCase.column)
+		bin, err := aField.ensureValidValueType(testCase.value)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectBin, bin, testCase.description)
+		actual, err := aField.tag.decodeTime(bin)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.True(t, testCase.expect.Equal(actual), testCase.description)
+		assert.Equal(t, testCase.expect.Location(), actual.Location(), testCase.description)
+	}
+}
+
+func Test_timeTagValidation(t *testing.T) {
+	_, err := ParseTag("ts,unixsec,unixms")
+	assert.NotNil(t, err)
+	_, err = ParseTag("ts,tz=Invalid/Zone")
+	assert.NotNil(t, err)
+	tag, err := ParseTag("ts,format='2006-01-02, 15:04',tz=UTC")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "2006-01-02, 15:04", tag.TimeFormat)
+		assert.Equal(t, time.UTC, tag.TimeZone)
+	}
+}
@@ -15,6 +15,8 @@ func (s *Statement) parseTruncateTable(sql string) error {
 }
 
 func (s *Statement) handleTruncateTable(ctx context.Context) (driver.Result, error) {
-	err := s.truncateWithCtx(ctx, nil, s.namespace, s.set, nil)
-	return &result{}, err
+	if err := s.truncateWithCtx(ctx, nil, s.namespace, s.set, nil); err != nil {
+		return &result{}, err
+	}
+	return &result{}, s.truncateChunks(ctx)
 }
@@ -8,11 +8,25 @@ func registerSet(aSet *set) error {
 	return globalSets.Register(aSet)
 }
 
-// RegisterSet register set
-func RegisterSet(xType *x.Type, options ...Option) error {
+func newSet(xType *x.Type, options ...Option) *set {
 	aSet := &set{xType: xType}
 	for _, option := range options {
 		option(aSet)
 	}
-	return globalSets.Register(aSet)
+	return aSet
+}
+
+// RegisterSet register set
+func RegisterSet(xType *x.Type, options ...Option) error {
+	return globalSets.RegisterSet(xType, options...)
+}
+
+// ReplaceSet replaces global set definition
+func ReplaceSet(xType *x.Type, options ...Option) error {
+	return globalSets.ReplaceSet(xType, options...)
+}
+
+// UnregisterSet removes global set, it returns false if the set was not registered
+func UnregisterSet(name string) bool {
+	return globalSets.UnregisterSet(name)
 }
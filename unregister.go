@@ -0,0 +1,63 @@
+package aerospike
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/viant/sqlparser"
+)
+
+// kindUnregisterSet represents UNREGISTER [GLOBAL] SET name statement
+const kindUnregisterSet sqlparser.Kind = "unregister set"
+
+var (
+	unregisterSetExpr = regexp.MustCompile(`(?i)^\s*UNREGISTER\s+(GLOBAL\s+)?SET\s+([^\s;]+)\s*;?\s*$`)
+	replaceSetExpr    = regexp.MustCompile(`(?i)^\s*REPLACE(\s+GLOBAL)?\s+SET\s`)
+)
+
+type unregisterSet struct {
+	Name   string
+	Global bool
+}
+
+// isReplaceSet returns true for REPLACE [GLOBAL] SET statement, it uses REGISTER SET syntax
+func isReplaceSet(SQL string) bool {
+	return replaceSetExpr.MatchString(SQL)
+}
+
+// asRegisterSet rewrites REPLACE [GLOBAL] SET statement to REGISTER [GLOBAL] SET
+func asRegisterSet(SQL string) string {
+	loc := replaceSetExpr.FindStringIndex(SQL)
+	if loc == nil {
+		return SQL
+	}
+	prefix := SQL[:loc[1]]
+	idx := strings.Index(strings.ToUpper(prefix), "REPLACE")
+	return prefix[:idx] + "REGISTER" + SQL[idx+len("REPLACE"):]
+}
+
+func (s *Statement) prepareUnregisterSet(SQL string) error {
+	match := unregisterSetExpr.FindStringSubmatch(SQL)
+	if match == nil {
+		return fmt.Errorf("invalid unregister set statement: %v", SQL)
+	}
+	s.unregister = &unregisterSet{Name: strings.ReplaceAll(match[2], "`", ""), Global: match[1] != ""}
+	return nil
+}
+
+// handleUnregisterSet removes set from statement registry, or with GLOBAL from global and statement registries
+func (s *Statement) handleUnregisterSet() (driver.Result, error) {
+	name := s.unregister.Name
+	removed := s.sets.UnregisterSet(name)
+	if s.unregister.Global {
+		removed = globalSets.UnregisterSet(name) || removed
+	} else if !removed && globalSets.Has(name) {
+		return nil, fmt.Errorf("unable to unregister set %v: set is registered globally, use UNREGISTER GLOBAL SET", name)
+	}
+	if !removed {
+		return nil, fmt.Errorf("unable to unregister set %v: set is not registered", name)
+	}
+	return &result{totalRows: 1}, nil
+}
@@ -8,11 +8,13 @@ import (
 	"github.com/viant/sqlparser"
 	"github.com/viant/sqlparser/expr"
 	"reflect"
+	"strings"
 )
 
 func (s *Statement) prepareUpdate(sql string) error {
 	var err error
-	if s.update, err = sqlparser.ParseUpdate(sql); err != nil {
+	sql, s.returning = splitReturning(sql)
+	if s.update, err = sqlparser.ParseUpdate(quotePseudoColumns(sql)); err != nil {
 		return err
 	}
 	s.setSet(sqlparser.Stringify(s.update.Target.X))
@@ -34,13 +36,93 @@ func (s *Statement) handleUpdate(ctx context.Context, args []driver.NamedValue)
 	var putBins = map[string]interface{}{}
 	var addBins = map[string]interface{}{}
 	var subBins = map[string]interface{}{}
+	var cdtBins = map[string]bool{}
+	s.generation = nil
+
+	var expiration *uint32
 
 	for _, item := range s.update.Set {
 		column := sqlparser.Stringify(item.Column)
+		if path := s.mapper.lookupPath(column); path != nil {
+			if item.IsExpr() {
+				return fmt.Errorf("unsupported nested column %v expression: %v", column, sqlparser.Stringify(item.Expr))
+			}
+			itemValue, err := item.Value()
+			if err != nil {
+				return err
+			}
+			value := itemValue.Value
+			if itemValue.Placeholder {
+				value = args[j].Value
+				j++
+			}
+			aSet, err := s.lookupSet()
+			if err != nil {
+				return err
+			}
+			op, err := pathPutOperation(aSet, path, value)
+			if err != nil {
+				return err
+			}
+			operates = append(operates, op)
+			cdtBins[path[0].Column()] = true
+			continue
+		}
 		aField := s.mapper.getField(column)
-		if aField == nil {
+		if aField == nil && !isPseudoColumn(column) {
 			return fmt.Errorf("unable to find field %v in type %s", column, s.recordType.String())
 		}
+		if strings.ToLower(strings.Trim(column, "`")) == generationColumn || (aField != nil && aField.tag.IsGeneration) {
+			return fmt.Errorf("unable to update read-only generation column %v", column)
+		}
+		call, isCall := item.Expr.(*expr.Call)
+		if aField == nil || aField.tag.IsTTL {
+			if item.IsExpr() || isCall {
+				return fmt.Errorf("unsupported ttl column %v expression: %v", column, sqlparser.Stringify(item.Expr))
+			}
+			itemValue, err := item.Value()
+			if err != nil {
+				return err
+			}
+			value := itemValue.Value
+			if itemValue.Placeholder {
+				value = args[j].Value
+				j++
+			}
+			ttl, ok, err := asExpiration(value)
+			if err != nil {
+				return err
+			}
+			if ok {
+				expiration = &ttl
+			}
+			continue
+		}
+		if isCall {
+			if s.collectionBin != "" {
+				return fmt.Errorf("unsupported %v column function %v with %v collection bin", column, sqlparser.Stringify(call.X), s.collectionBin)
+			}
+			if aField.tag.IsEncrypted {
+				return fmt.Errorf("unsupported %v column function %v on encrypted column", column, sqlparser.Stringify(call.X))
+			}
+			if aField.tag.Codec != "" {
+				return fmt.Errorf("unsupported %v column function %v on %v codec column", column, sqlparser.Stringify(call.X), aField.tag.Codec)
+			}
+			if aField.tag.Compress != "" {
+				return fmt.Errorf("unsupported %v column function %v on compressed column", column, sqlparser.Stringify(call.X))
+			}
+			aSet, err := s.lookupSet()
+			if err != nil {
+				return err
+			}
+			ops, err := s.cdtOperations(aSet, aField, call, args, &j)
+			if err != nil {
+				return err
+			}
+			operates = append(operates, ops...)
+			cdtBins[aField.Column()] = true
+			continue
+		}
 		var value interface{}
 		if item.IsExpr() {
 			binary := item.Expr.(*expr.Binary)
@@ -58,6 +140,15 @@ func (s *Statement) handleUpdate(ctx context.Context, args []driver.NamedValue)
 					addValue = args[j].Value
 					j++
 				}
+				if aField.tag.IsEncrypted {
+					return fmt.Errorf("unsupported %v operator on encrypted column %v", binary.Op, column)
+				}
+				if aField.tag.Codec != "" {
+					return fmt.Errorf("unsupported %v operator on %v codec column %v", binary.Op, aField.tag.Codec, column)
+				}
+				if aField.tag.Compress != "" {
+					return fmt.Errorf("unsupported %v operator on compressed column %v", binary.Op, column)
+				}
 				addValue, err = aField.ensureValidValueType(addValue)
 				if err != nil {
 					return err
@@ -78,13 +169,28 @@ func (s *Statement) handleUpdate(ctx context.Context, args []driver.NamedValue)
 			if itemValue.Placeholder {
 				value = args[j].Value
 				j++
+				if aField.tag.OmitEmpty && isEmptyValue(value) {
+					continue
+				}
 			} else {
 				value = itemValue.Value
 			}
+			if isNullValue(value) {
+				putBins[aField.Column()] = nil
+				continue
+			}
 			value, err = aField.ensureValidValueType(value)
 			if err != nil {
+				if aField.tag.Codec != "" {
+					return codecError(s.set, aField, err)
+				}
 				return err
 			}
+			if aField.tag.IsEncrypted {
+				if value, err = s.encrypt(aField, value); err != nil {
+					return err
+				}
+			}
 			// Normalize custom []byte-like types (e.g., json.RawMessage) to []byte so Aerospike stores blob
 			if value != nil {
 				v := reflect.ValueOf(value)
@@ -104,11 +210,34 @@ func (s *Statement) handleUpdate(ctx context.Context, args []driver.NamedValue)
 		return err
 	}
 
+	aSet, err := s.lookupSet()
+	if err != nil {
+		return err
+	}
+	keys, err := s.buildKeys()
+	if err != nil {
+		return err
+	}
+	if len(keys) != 1 {
+		return fmt.Errorf("update statement must have one pk")
+	}
+
+	if isDryRun("update") {
+		return nil
+	}
+
+	writePolicy := s.writePolicy(aSet, false)
+	if expiration != nil {
+		writePolicy.Expiration = *expiration
+	}
+	writePolicy.FilterExpression = s.filterExpression
+	var chunks *chunkWrite
+	var mapKeys []interface{}
 	if s.collectionType.IsMap() {
 		if len(s.mapKeyValues) != 1 {
 			return fmt.Errorf("update statement map must have one map mapKey")
 		}
-		mapPolicy := as.NewMapPolicy(as.MapOrder.KEY_ORDERED, as.MapWriteMode.UPDATE)
+		mapPolicy := aSet.mapUpdatePolicy()
 		// ensure map key is not a pointer type
 		mk := s.mapKeyValues[0]
 		if rv := reflect.ValueOf(mk); rv.IsValid() && rv.Kind() == reflect.Ptr {
@@ -118,16 +247,25 @@ func (s *Statement) handleUpdate(ctx context.Context, args []driver.NamedValue)
 				mk = rv.Elem().Interface()
 			}
 		}
+		mapKeys = append(mapKeys, mk)
 		binKey := as.CtxMapKey(as.NewValue(mk))
 		// If the update only sets the payload column and no add/sub, replace entire entry value
 		payload := findPayloadColumn(s.mapper)
 		if payload != "" && len(addBins) == 0 && len(subBins) == 0 && len(putBins) == 1 {
 			if v, ok := putBins[payload]; ok {
-				// key already normalized in mk
-				operates = append(operates, as.MapPutOp(mapPolicy, s.collectionBin, as.NewValue(mk), v))
+				// key already normalized in mk, NULL payload removes map entry
+				if v == nil {
+					operates = append(operates, as.MapRemoveByKeyOp(s.collectionBin, as.NewValue(mk), as.MapReturnType.NONE))
+				} else {
+					operates = append(operates, as.MapPutOp(mapPolicy, s.collectionBin, as.NewValue(mk), v))
+				}
 			} else {
 				// fall back to nested ops
 				for key, value := range putBins {
+					if value == nil {
+						operates = append(operates, as.MapRemoveByKeyOp(s.collectionBin, key, as.MapReturnType.NONE, binKey))
+						continue
+					}
 					operates = append(operates, as.MapPutOp(mapPolicy, s.collectionBin, key, value, binKey))
 				}
 			}
@@ -140,10 +278,17 @@ func (s *Statement) handleUpdate(ctx context.Context, args []driver.NamedValue)
 				operates = append(operates, as.MapDecrementOp(mapPolicy, s.collectionBin, key, value, binKey))
 			}
 			for key, value := range putBins {
+				if value == nil {
+					operates = append(operates, as.MapRemoveByKeyOp(s.collectionBin, key, as.MapReturnType.NONE, binKey))
+					continue
+				}
 				operates = append(operates, as.MapPutOp(mapPolicy, s.collectionBin, key, value, binKey))
 			}
 		}
 	} else if s.collectionType.IsArray() || s.collectionType == "" {
+		if chunks, err = s.writeChunks(ctx, keys[0], putBins, writePolicy); err != nil {
+			return err
+		}
 		for key, value := range addBins {
 			operates = append(operates, as.AddOp(as.NewBin(key, value)))
 		}
@@ -155,32 +300,70 @@ func (s *Statement) handleUpdate(ctx context.Context, args []driver.NamedValue)
 		}
 	}
 
-	keys, err := s.buildKeys()
-	if err != nil {
-		return err
-	}
-	if len(keys) != 1 {
-		return fmt.Errorf("update statement must have one pk")
+	if len(operates) == 0 {
+		operates = append(operates, as.TouchOp())
 	}
-
-	if isDryRun("update") {
-		return nil
+	if s.generation != nil {
+		expectGeneration(writePolicy, *s.generation)
 	}
-
-	aSet, err := s.lookupSet()
-	if err != nil {
-		return err
+	if s.collectionType.IsMap() {
+		operates = s.appendReturningMapEntries(writePolicy, operates, mapKeys)
+	} else {
+		operates = s.appendReturning(writePolicy, operates)
 	}
-
-	writePolicy := s.writePolicy(aSet, false)
 	for _, key := range keys {
-		if _, err = s.operateWithCtx(ctx, writePolicy, key, operates); err != nil {
+		pkValue := key.Value().GetObject()
+		if s.collectionType.IsMap() && aSet.isSharded() {
+			if key, err = as.NewKey(s.namespace, s.set, aSet.shardKeyValue(pkValue, mapKeys[0])); err != nil {
+				return err
+			}
+			operates = append(operates, s.shardPkOperations(aSet, pkValue)...)
+		}
+		result, opErr := s.operateWithCtx(ctx, writePolicy, key, operates)
+		if IsFilteredOut(opErr) {
+			s.affected = 0
+			_ = s.completeChunks(ctx, chunks, opErr)
+			continue
+		}
+		if err = s.completeChunks(ctx, chunks, opErr); err != nil {
+			if s.generation != nil {
+				return generationError(err, key, *s.generation)
+			}
 			return err
 		}
+		if s.collectionType.IsMap() {
+			if err = s.addReturnedMapEntries(result, pkValue, mapKeys); err != nil {
+				return err
+			}
+			continue
+		}
+		expiration := writePolicy.Expiration
+		if result != nil && expiration != as.TTLDontUpdate {
+			expiration = result.Expiration
+		}
+		if err = s.renewChunks(ctx, key, putBins, expiration); err != nil {
+			return err
+		}
+		written := writtenBins(putBins, addBins, subBins)
+		for bin := range cdtBins {
+			written[bin] = true
+		}
+		s.addReturned(result, written)
 	}
 	return nil
 }
 
+// writtenBins returns names of bins modified by write operations
+func writtenBins(binMaps ...map[string]interface{}) map[string]bool {
+	var result = make(map[string]bool)
+	for _, bins := range binMaps {
+		for name := range bins {
+			result[name] = true
+		}
+	}
+	return result
+}
+
 func negate(value interface{}) interface{} {
 	switch v := value.(type) {
 	case int: